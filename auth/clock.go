@@ -0,0 +1,28 @@
+package auth
+
+import "time"
+
+// Clock reports the current time. Inject a Clock to make expiry checks
+// deterministic in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is a Clock that reports the real time.
+type systemClock struct{}
+
+// Now returns the current local time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock is the default Clock, which reports the real time.
+var SystemClock Clock = systemClock{}
+
+// clockOrDefault returns clock, or SystemClock if clock is nil.
+func clockOrDefault(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+	return clock
+}
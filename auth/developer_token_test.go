@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixedClock is a Clock that always reports the same time.
+type fixedClock time.Time
+
+// Now returns the fixed time.
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// newECKeyPEM returns a freshly generated P-256 key pair, PEM-encoded as in a
+// .p8 file from Apple and as a public key.
+func newECKeyPEM(t *testing.T) (privateKey, publicKey []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	private, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public})
+}
+
+// newRSAKeyPEM returns a freshly generated RSA private key PEM-encoded in a
+// block of the given type.
+func newRSAKeyPEM(t *testing.T, blockType string) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	der := x509.MarshalPKCS1PrivateKey(key)
+	if blockType == "PRIVATE KEY" {
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("failed to marshal RSA key: %v", err)
+		}
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func TestNewDeveloperTokenInvalidPrivateKey(t *testing.T) {
+	tests := []struct {
+		name string
+		key  []byte
+		hint string
+	}{
+		{"empty", nil, "the key is empty"},
+		{"whitespace", []byte(" \n\t"), "the key is empty"},
+		{"not PEM", []byte("MIGTAgEAMBMGByqGSM49AgEGCCqGSM49AwEHBHkwdwIBAQQg"), "the key is not PEM encoded"},
+		{"RSA block", newRSAKeyPEM(t, "RSA PRIVATE KEY"), `found "RSA PRIVATE KEY"`},
+		{"RSA PKCS #8", newRSAKeyPEM(t, "PRIVATE KEY"), "the key is not an EC P-256 key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDeveloperTokenFromConfig(DeveloperTokenConfig{
+				TeamID:     "TEAMID",
+				KeyID:      "KEYID",
+				PrivateKey: tt.key,
+				ExpiresAt:  time.Now().Add(time.Hour),
+			})
+			if !errors.Is(err, ErrInvalidPrivateKey) {
+				t.Fatalf("error = %v, want ErrInvalidPrivateKey", err)
+			}
+			if !strings.Contains(err.Error(), tt.hint) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.hint)
+			}
+		})
+	}
+}
+
+func TestDeveloperTokenVerify(t *testing.T) {
+	privateKey, publicKey := newECKeyPEM(t)
+	_, otherPublicKey := newECKeyPEM(t)
+
+	issuedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	token, err := NewDeveloperTokenFromConfig(DeveloperTokenConfig{
+		TeamID:     "TEAMID",
+		KeyID:      "KEYID",
+		PrivateKey: privateKey,
+		ExpiresAt:  issuedAt.Add(time.Hour),
+		Clock:      fixedClock(issuedAt),
+	})
+	if err != nil {
+		t.Fatalf("NewDeveloperTokenFromConfig() error = %v", err)
+	}
+
+	if err := token.Verify(publicKey); err != nil {
+		t.Errorf("Verify() of a valid token error = %v", err)
+	}
+
+	if err := token.Verify(otherPublicKey); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() with the wrong key error = %v, want ErrInvalidSignature", err)
+	}
+
+	token.SetClock(fixedClock(issuedAt.Add(2 * time.Hour)))
+	if err := token.Verify(publicKey); !errors.Is(err, ErrInvalidClaims) {
+		t.Errorf("Verify() of an expired token error = %v, want ErrInvalidClaims", err)
+	}
+}
+
+func TestDeveloperTokenVerifyRejectsLongLifetime(t *testing.T) {
+	privateKey, publicKey := newECKeyPEM(t)
+
+	token, err := NewDeveloperTokenFromConfig(DeveloperTokenConfig{
+		TeamID:     "TEAMID",
+		KeyID:      "KEYID",
+		PrivateKey: privateKey,
+		ExpiresAt:  time.Now().Add(MaxTokenLifetime + time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("NewDeveloperTokenFromConfig() error = %v", err)
+	}
+
+	if err := token.Verify(publicKey); !errors.Is(err, ErrInvalidClaims) {
+		t.Errorf("Verify() error = %v, want ErrInvalidClaims", err)
+	}
+}
@@ -26,8 +26,13 @@ type UserTokenManager struct {
 	oauthConfig    *oauth2.Config
 	developerToken *DeveloperToken
 	tokenCache     TokenCache
+	clock          Clock
 }
 
+// tokenExpiryDelta is how long before its expiry a cached user token is
+// treated as expired, matching the oauth2 package.
+const tokenExpiryDelta = 10 * time.Second
+
 // TokenCache interface for storing and retrieving user tokens.
 type TokenCache interface {
 	Get(userID string) (*oauth2.Token, error)
@@ -85,14 +90,24 @@ func NewUserTokenManager(developerToken *DeveloperToken, clientID, redirectURL s
 	}
 }
 
+// OAuthConfig returns a copy of the underlying OAuth2 configuration.
+// Modifying the returned value does not affect the manager.
+func (m *UserTokenManager) OAuthConfig() *oauth2.Config {
+	config := *m.oauthConfig
+	config.Scopes = append([]string(nil), m.oauthConfig.Scopes...)
+	return &config
+}
+
 // GetAuthURL returns the URL to redirect the user to for authorization.
-func (m *UserTokenManager) GetAuthURL(state string) string {
-	return m.oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)
+// Any additional options are applied after oauth2.AccessTypeOffline.
+func (m *UserTokenManager) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	opts = append([]oauth2.AuthCodeOption{oauth2.AccessTypeOffline}, opts...)
+	return m.oauthConfig.AuthCodeURL(state, opts...)
 }
 
 // ExchangeCode exchanges an authorization code for a user token.
-func (m *UserTokenManager) ExchangeCode(ctx context.Context, code string) (*oauth2.Token, error) {
-	return m.oauthConfig.Exchange(ctx, code)
+func (m *UserTokenManager) ExchangeCode(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return m.oauthConfig.Exchange(ctx, code, opts...)
 }
 
 // RefreshToken refreshes an expired user token.
@@ -112,7 +127,7 @@ func (m *UserTokenManager) GetUserToken(ctx context.Context, userID string) (*oa
 		return nil, err
 	}
 
-	if token.Valid() {
+	if m.tokenValid(token) {
 		return token, nil
 	}
 
@@ -129,6 +144,26 @@ func (m *UserTokenManager) GetUserToken(ctx context.Context, userID string) (*oa
 	return newToken, nil
 }
 
+// SetClock sets the clock used to decide whether a cached user token has
+// expired. A nil clock restores SystemClock.
+func (m *UserTokenManager) SetClock(clock Clock) {
+	m.clock = clock
+}
+
+// tokenValid reports whether token is non-nil, has an access token and does not
+// expire within tokenExpiryDelta, according to the manager's clock.
+func (m *UserTokenManager) tokenValid(token *oauth2.Token) bool {
+	if token == nil || token.AccessToken == "" {
+		return false
+	}
+
+	if token.Expiry.IsZero() {
+		return true
+	}
+
+	return clockOrDefault(m.clock).Now().Add(tokenExpiryDelta).Before(token.Expiry)
+}
+
 // RequestUserToken requests a user token from the Apple Music API.
 func (m *UserTokenManager) RequestUserToken(ctx context.Context, musicUserToken string) (*UserTokenResponse, error) {
 	data := url.Values{}
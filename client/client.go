@@ -4,6 +4,7 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/marcusziade/musickitkat/errors"
@@ -28,6 +30,16 @@ const DefaultUserAgent = "MusicKitKat/0.1.0"
 // DefaultTimeout is the default request timeout.
 const DefaultTimeout = 30 * time.Second
 
+// developerTokenHint describes the most common cause of a 401 when a developer token is present.
+const developerTokenHint = "verify that the developer token's KeyID matches the private key it was signed with, " +
+	"and that the TeamID is the team that owns the key"
+
+// DefaultUserTokenHeader is the default header used to send the user token.
+const DefaultUserTokenHeader = "Music-User-Token"
+
+// IdempotencyKeyHeader is the header used to send a client-generated idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 // LogLevel defines the verbosity of client logging
 type LogLevel int
 
@@ -47,6 +59,10 @@ type Client struct {
 	// HTTP client
 	client *http.Client
 
+	// Transport cloned by transport() for this client to configure, nil until
+	// the transport is first configured
+	ownTransport *http.Transport
+
 	// Base URL for API requests
 	baseURL string
 
@@ -59,17 +75,31 @@ type Client struct {
 	// Additional headers
 	headers map[string]string
 
-	// Developer token
+	// Developer token, guarded by tokenMu so it can be replaced while
+	// requests are in flight
+	tokenMu        sync.RWMutex
 	developerToken string
 
 	// User token
 	userToken string
 
+	// Header used to send the user token
+	userTokenHeader string
+
+	// ETag cache for conditional requests, nil when disabled
+	etags *etagCache
+
 	// Logger instance
 	logger *log.Logger
 
 	// Log level
 	logLevel LogLevel
+
+	// Maximum number of body bytes written to debug logs
+	debugBodyLimit int
+
+	// Generator of request IDs, nil when disabled
+	requestID func() string
 }
 
 // ClientOption is a function that configures a Client.
@@ -103,6 +133,14 @@ func WithHeader(key, value string) ClientOption {
 	}
 }
 
+// WithUserTokenHeader sets the name of the header used to send the user token,
+// for example when a gateway renames it. The default is DefaultUserTokenHeader.
+func WithUserTokenHeader(name string) ClientOption {
+	return func(c *Client) {
+		c.userTokenHeader = name
+	}
+}
+
 // WithHTTPClient sets the HTTP client.
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *Client) {
@@ -127,13 +165,15 @@ func WithLogLevel(level LogLevel) ClientOption {
 // NewClient creates a new Client with the provided options.
 func NewClient(options ...ClientOption) *Client {
 	client := &Client{
-		client:     &http.Client{Timeout: DefaultTimeout},
-		baseURL:    DefaultBaseURL,
-		apiVersion: DefaultAPIVersion,
-		userAgent:  DefaultUserAgent,
-		headers:    make(map[string]string),
-		logger:     log.New(io.Discard, "", log.LstdFlags),
-		logLevel:   LogLevelNone,
+		client:          &http.Client{Timeout: DefaultTimeout},
+		baseURL:         DefaultBaseURL,
+		apiVersion:      DefaultAPIVersion,
+		userAgent:       DefaultUserAgent,
+		headers:         make(map[string]string),
+		userTokenHeader: DefaultUserTokenHeader,
+		logger:          log.New(io.Discard, "", log.LstdFlags),
+		logLevel:        LogLevelNone,
+		debugBodyLimit:  DefaultDebugBodyLimit,
 	}
 
 	// Apply all client options
@@ -144,6 +184,11 @@ func NewClient(options ...ClientOption) *Client {
 	return client
 }
 
+// SetBaseURL sets the base URL.
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = baseURL
+}
+
 // SetHTTPClient sets the HTTP client.
 func (c *Client) SetHTTPClient(client *http.Client) {
 	c.client = client
@@ -154,16 +199,46 @@ func (c *Client) SetTimeout(timeout time.Duration) {
 	c.client.Timeout = timeout
 }
 
-// SetDeveloperToken sets the developer token.
+// SetDeveloperToken sets the developer token. It is safe to call while
+// requests are in flight, for example to replace a token before it expires.
 func (c *Client) SetDeveloperToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
 	c.developerToken = token
 }
 
-// SetUserToken sets the user token.
+// SetUserToken sets the user token. It is not safe to call while requests are
+// in flight; to make requests on behalf of different users with a shared client,
+// attach each user's token to the request context with WithUserToken instead.
 func (c *Client) SetUserToken(token string) {
 	c.userToken = token
 }
 
+// SetUserTokenHeader sets the name of the header used to send the user token.
+func (c *Client) SetUserTokenHeader(name string) {
+	c.userTokenHeader = name
+}
+
+// HasUserToken reports whether a user token is set on the client.
+func (c *Client) HasUserToken() bool {
+	return c.userToken != ""
+}
+
+// HasUserTokenFor reports whether requests made with ctx carry a user token,
+// either attached to ctx with WithUserToken or set on the client.
+func (c *Client) HasUserTokenFor(ctx context.Context) bool {
+	return c.userTokenFor(ctx) != ""
+}
+
+// userTokenFor returns the user token for requests made with ctx: a token
+// attached to ctx with WithUserToken wins over the client's token.
+func (c *Client) userTokenFor(ctx context.Context) string {
+	if token := UserToken(ctx); token != "" {
+		return token
+	}
+	return c.userToken
+}
+
 // SetLogLevel sets the logging level.
 func (c *Client) SetLogLevel(level LogLevel) {
 	c.logLevel = level
@@ -174,6 +249,33 @@ func (c *Client) SetLogger(logger *log.Logger) {
 	c.logger = logger
 }
 
+// NewUUID returns a random (version 4) UUID string.
+func NewUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate UUID: %w", err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// RelativePath converts an href returned by the API (such as a "next" link)
+// into a path that can be passed to Get.
+func (c *Client) RelativePath(href string) string {
+	path := strings.TrimPrefix(href, c.baseURL)
+	path = strings.TrimPrefix(path, "/")
+	return strings.TrimPrefix(path, c.apiVersion+"/")
+}
+
+// Logf logs a message at the specified level with the client's logger, for
+// components built on the client.
+func (c *Client) Logf(level LogLevel, format string, v ...interface{}) {
+	c.log(level, format, v...)
+}
+
 // log logs a message at the specified level.
 func (c *Client) log(level LogLevel, format string, v ...interface{}) {
 	if c.logLevel >= level {
@@ -181,82 +283,156 @@ func (c *Client) log(level LogLevel, format string, v ...interface{}) {
 	}
 }
 
+// logContext logs a message at the specified level, tagged with the log user ID
+// and request ID attached to ctx, if any.
+func (c *Client) logContext(ctx context.Context, level LogLevel, format string, v ...interface{}) {
+	if requestID := RequestID(ctx); requestID != "" {
+		format = "[request %s] " + format
+		v = append([]interface{}{requestID}, v...)
+	}
+
+	if userID := LogUserID(ctx); userID != "" {
+		format = "[user %s] " + format
+		v = append([]interface{}{userID}, v...)
+	}
+	c.log(level, format, v...)
+}
+
+// responseContext returns the context of the request that produced resp.
+func responseContext(resp *http.Response) context.Context {
+	if resp.Request == nil {
+		return context.Background()
+	}
+	return resp.Request.Context()
+}
+
 // logRequest logs an HTTP request.
 func (c *Client) logRequest(req *http.Request) {
-	if c.logLevel >= LogLevelInfo {
-		c.logger.Printf("REQUEST: %s %s", req.Method, req.URL.String())
-	}
+	c.logContext(req.Context(), LogLevelInfo, "REQUEST: %s %s", req.Method, req.URL.String())
 
 	if c.logLevel >= LogLevelDebug {
-		dump, err := httputil.DumpRequestOut(req, true)
+		// The body is logged by NewRequest, within the debug body limit
+		dump, err := httputil.DumpRequestOut(req, false)
 		if err != nil {
-			c.log(LogLevelError, "Failed to dump request: %v", err)
+			c.logContext(req.Context(), LogLevelError, "Failed to dump request: %v", err)
 			return
 		}
-		c.logger.Printf("REQUEST DUMP:\n%s", dump)
+		c.logContext(req.Context(), LogLevelDebug, "REQUEST DUMP:\n%s", dump)
 	}
 }
 
 // logResponse logs an HTTP response.
 func (c *Client) logResponse(resp *http.Response) {
-	if c.logLevel >= LogLevelInfo {
-		c.logger.Printf("RESPONSE: %d %s", resp.StatusCode, resp.Status)
-	}
+	c.logContext(responseContext(resp), LogLevelInfo, "RESPONSE: %d %s", resp.StatusCode, resp.Status)
 
 	if c.logLevel >= LogLevelDebug {
-		dump, err := httputil.DumpResponse(resp, true)
+		// The body is logged when it is read, within the debug body limit
+		dump, err := httputil.DumpResponse(resp, false)
 		if err != nil {
-			c.log(LogLevelError, "Failed to dump response: %v", err)
+			c.logContext(responseContext(resp), LogLevelError, "Failed to dump response: %v", err)
 			return
 		}
-		c.logger.Printf("RESPONSE DUMP:\n%s", dump)
+		c.logContext(responseContext(resp), LogLevelDebug, "RESPONSE DUMP:\n%s", dump)
 	}
 }
 
 // buildURL builds the full URL for a request.
 func (c *Client) buildURL(path string) string {
-	return fmt.Sprintf("%s/%s/%s", c.baseURL, c.apiVersion, path)
+	baseURL := strings.TrimRight(c.baseURL, "/")
+	apiVersion := strings.Trim(c.apiVersion, "/")
+	return fmt.Sprintf("%s/%s/%s", baseURL, apiVersion, normalizePath(path))
+}
+
+// requiresUserToken reports whether a request path is under me/, which
+// requires a user token.
+func requiresUserToken(path string) bool {
+	path = normalizePath(path)
+	return path == "me" || strings.HasPrefix(path, "me/") || strings.HasPrefix(path, "me?")
 }
 
-// NewRequest creates a new HTTP request.
+// normalizePath collapses duplicate slashes in the path portion of path and
+// trims leading and trailing slashes. The query string is left untouched.
+func normalizePath(path string) string {
+	query := ""
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path, query = path[:i], path[i:]
+	}
+
+	segments := strings.Split(path, "/")
+	kept := segments[:0]
+	for _, segment := range segments {
+		if segment != "" {
+			kept = append(kept, segment)
+		}
+	}
+
+	return strings.Join(kept, "/") + query
+}
+
+// NewRequest creates a new HTTP request. Requests under me/ require a user
+// token; without one, NewRequest fails with errors.ErrUserTokenRequired
+// instead of letting the API reject the request.
 func (c *Client) NewRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	ctx = c.withRequestID(ctx)
+
+	userToken := c.userTokenFor(ctx)
+	if userToken == "" && requiresUserToken(path) {
+		c.logContext(ctx, LogLevelError, "%s is required for %s but is missing", c.userTokenHeader, path)
+		return nil, fmt.Errorf("%w: %s requires a user token; set it with WithUserToken()", errors.ErrUserTokenRequired, path)
+	}
+
 	url := c.buildURL(path)
-	c.log(LogLevelInfo, "Creating new request: %s %s", method, url)
+	c.logContext(ctx, LogLevelInfo, "Creating new request: %s %s", method, url)
 
-	var buf io.ReadWriter
+	// The body is buffered so that it can be re-sent by Do
+	var data []byte
+	var reader io.Reader
 	if body != nil {
-		buf = new(bytes.Buffer)
+		buf := new(bytes.Buffer)
 		err := json.NewEncoder(buf).Encode(body)
 		if err != nil {
-			c.log(LogLevelError, "Failed to encode request body: %v", err)
+			c.logContext(ctx, LogLevelError, "Failed to encode request body: %v", err)
 			return nil, fmt.Errorf("failed to encode request body: %w", err)
 		}
+		data = buf.Bytes()
+		reader = bytes.NewReader(data)
 
 		// Log the request body
 		if c.logLevel >= LogLevelDebug {
-			rawBody, _ := json.Marshal(body)
-			c.logger.Printf("REQUEST BODY: %s", string(rawBody))
+			c.logContext(ctx, LogLevelDebug, "REQUEST BODY: %s", c.debugBody(bytes.TrimSpace(data), "application/json"))
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, buf)
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
-		c.log(LogLevelError, "Failed to create request: %v", err)
+		c.logContext(ctx, LogLevelError, "Failed to create request: %v", err)
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set default headers
+	if data != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+	}
+
+	// Set default headers; Content-Type only describes a request body
 	req.Header.Set("User-Agent", c.userAgent)
-	req.Header.Set("Content-Type", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("Accept", "application/json")
 
 	// Set authentication headers
-	if c.developerToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.developerToken)
+	c.tokenMu.RLock()
+	developerToken := c.developerToken
+	c.tokenMu.RUnlock()
+
+	if developerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+developerToken)
 	}
 
-	if c.userToken != "" {
-		req.Header.Set("Music-User-Token", c.userToken)
+	if userToken != "" {
+		req.Header.Set(c.userTokenHeader, userToken)
 	}
 
 	// Set additional headers
@@ -264,18 +440,42 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body inter
 		req.Header.Set(key, value)
 	}
 
+	if requestID := RequestID(ctx); requestID != "" {
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+
+	// Set per-request headers, which must not replace the authentication headers
+	for key, value := range Headers(ctx) {
+		switch http.CanonicalHeaderKey(key) {
+		case "Authorization", http.CanonicalHeaderKey(c.userTokenHeader):
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+
 	c.logRequest(req)
 
 	return req, nil
 }
 
-// Do sends an HTTP request and returns an HTTP response.
+// Do sends an HTTP request and returns an HTTP response. Requests created with
+// NewRequest can be sent more than once, for example to retry a write: the body
+// is rewound with req.GetBody before each send.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	c.log(LogLevelInfo, "Sending request: %s %s", req.Method, req.URL.String())
+	c.logContext(req.Context(), LogLevelInfo, "Sending request: %s %s", req.Method, req.URL.String())
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			c.logContext(req.Context(), LogLevelError, "Failed to rewind request body: %v", err)
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		req.Body = body
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		c.log(LogLevelError, "Failed to send request: %v", err)
+		c.logContext(req.Context(), LogLevelError, "Failed to send request: %v", err)
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
@@ -283,73 +483,101 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 
 	// Check for API errors
 	if resp.StatusCode >= 400 {
-		c.log(LogLevelError, "API returned error status: %d %s", resp.StatusCode, resp.Status)
+		// No response is returned on this path, so the body is closed here
+		defer drainAndClose(resp.Body)
+
+		c.logContext(req.Context(), LogLevelError, "API returned error status: %d %s", resp.StatusCode, resp.Status)
 
 		// Log response headers which might contain useful info
-		c.log(LogLevelDebug, "Error response headers:")
+		c.logContext(req.Context(), LogLevelDebug, "Error response headers:")
 		for key, values := range resp.Header {
 			for _, value := range values {
-				c.log(LogLevelDebug, "  %s: %s", key, value)
+				c.logContext(req.Context(), LogLevelDebug, "  %s: %s", key, value)
 			}
 		}
 
 		// Add specific guidance for authentication errors
 		if resp.StatusCode == 401 {
 			authHeader := resp.Request.Header.Get("Authorization")
-			c.log(LogLevelError, "Authentication failed (401 Unauthorized)")
+			c.logContext(req.Context(), LogLevelError, "Authentication failed (401 Unauthorized)")
 
 			// Check if developer token is present
 			if authHeader == "" || authHeader == "Bearer " {
-				c.log(LogLevelError, "Developer token is missing. Ensure you've set it with WithDeveloperToken()")
-				return nil, fmt.Errorf("API authentication error (status 401): Developer token is missing or invalid. " +
-					"Check your APPLE_TEAM_ID, APPLE_KEY_ID, APPLE_MUSIC_ID, and private key")
+				c.logContext(req.Context(), LogLevelError, "Developer token is missing. Ensure you've set it with WithDeveloperToken()")
+				apiErr := newStatusError(resp.StatusCode, "developer token is missing or invalid")
+				apiErr.Hint = "check your APPLE_TEAM_ID, APPLE_KEY_ID, APPLE_MUSIC_ID, and private key"
+				return nil, apiErr
 			}
 
 			// Check if User-Token is needed for this endpoint but not provided
 			path := resp.Request.URL.Path
 			if (strings.Contains(path, "/me/") || strings.Contains(path, "/library/")) &&
-				resp.Request.Header.Get("Music-User-Token") == "" {
-				c.log(LogLevelError, "Music-User-Token is required for this endpoint but is missing")
-				return nil, fmt.Errorf("API authentication error (status 401): Music-User-Token is required for %s but is missing. "+
-					"Use WithUserToken() to set the user token", path)
+				resp.Request.Header.Get(c.userTokenHeader) == "" {
+				c.logContext(req.Context(), LogLevelError, "%s is required for this endpoint but is missing", c.userTokenHeader)
+				apiErr := newStatusError(resp.StatusCode, fmt.Sprintf("%s is required for %s but is missing", c.userTokenHeader, path))
+				apiErr.Hint = "use WithUserToken() to set the user token"
+				return nil, apiErr
 			}
 		}
 
 		apiErr, err := c.parseErrorResponse(resp)
 		if err != nil {
-			c.log(LogLevelError, "Failed to parse error response: %v", err)
+			c.logContext(req.Context(), LogLevelError, "Failed to parse error response: %v", err)
 			// Add the status code to the error to make it more informative
 			return nil, fmt.Errorf("HTTP %d: failed to parse error response: %w",
 				resp.StatusCode, err)
 		}
+
+		// A 401 with a developer token present is most often a KeyID/private key
+		// mismatch, unless a user token was sent, which may have expired instead
+		if resp.StatusCode == 401 && resp.Request.Header.Get(c.userTokenHeader) == "" {
+			c.logContext(req.Context(), LogLevelError, "Developer token was rejected; %s", developerTokenHint)
+			if e, ok := apiErr.(*errors.APIError); ok {
+				e.Hint = developerTokenHint
+			} else {
+				apiErr = fmt.Errorf("%w (hint: %s)", apiErr, developerTokenHint)
+			}
+		}
+
 		return nil, apiErr
 	}
 
 	return resp, nil
 }
 
+// maxDrainBytes is the most that drainAndClose reads from a body before closing
+// it. Larger bodies are closed without being read, giving up the connection.
+const maxDrainBytes = 64 << 10
+
+// drainAndClose reads what remains of a response body and closes it, so that
+// the underlying connection can be reused.
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
+
 // parseErrorResponse parses an error response from the Apple Music API.
 func (c *Client) parseErrorResponse(resp *http.Response) (error, error) {
 	var apiErr errors.APIError
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		c.log(LogLevelError, "Failed to read error response body: %v", err)
+		c.logContext(responseContext(resp), LogLevelError, "Failed to read error response body: %v", err)
 		return nil, fmt.Errorf("failed to read error response body: %w", err)
 	}
 
 	// Log the raw error response body
-	c.log(LogLevelDebug, "Error response body: %s", string(body))
+	c.logContext(responseContext(resp), LogLevelDebug, "Error response body: %s", c.debugBody(body, resp.Header.Get("Content-Type")))
 
 	// Check if the body is empty or too short to be valid JSON
 	if len(body) == 0 {
-		c.log(LogLevelError, "Error response body is empty")
-		return fmt.Errorf("API error (status %d): empty response body", resp.StatusCode), nil
+		c.logContext(responseContext(resp), LogLevelError, "Error response body is empty")
+		return newStatusError(resp.StatusCode, "empty response body"), nil
 	}
 
 	if len(bytes.TrimSpace(body)) == 0 {
-		c.log(LogLevelError, "Error response body contains only whitespace")
-		return fmt.Errorf("API error (status %d): whitespace-only response body", resp.StatusCode), nil
+		c.logContext(responseContext(resp), LogLevelError, "Error response body contains only whitespace")
+		return newStatusError(resp.StatusCode, "whitespace-only response body"), nil
 	}
 
 	// Check if the body looks like JSON
@@ -362,9 +590,8 @@ func (c *Client) parseErrorResponse(resp *http.Response) (error, error) {
 		if len(contentSample) > 100 {
 			contentSample = contentSample[:100] + "..."
 		}
-		c.log(LogLevelError, "Error response is not JSON: %s", contentSample)
-		return fmt.Errorf("API error (status %d): non-JSON response: %s",
-			resp.StatusCode, contentSample), nil
+		c.logContext(responseContext(resp), LogLevelError, "Error response is not JSON: %s", contentSample)
+		return newStatusError(resp.StatusCode, "non-JSON response: "+contentSample), nil
 	}
 
 	// Restore the response body
@@ -373,8 +600,8 @@ func (c *Client) parseErrorResponse(resp *http.Response) (error, error) {
 	// Try to unmarshal as standard API error
 	err = json.Unmarshal(body, &apiErr)
 	if err != nil {
-		c.log(LogLevelError, "Failed to unmarshal error response: %v", err)
-		c.log(LogLevelDebug, "Unmarshalling failed for body: %s", string(body))
+		c.logContext(responseContext(resp), LogLevelError, "Failed to unmarshal error response: %v", err)
+		c.logContext(responseContext(resp), LogLevelDebug, "Unmarshalling failed for body: %s", c.debugBody(body, resp.Header.Get("Content-Type")))
 
 		// Create a fallback error with the status code and raw body preview
 		contentSample := string(body)
@@ -382,45 +609,63 @@ func (c *Client) parseErrorResponse(resp *http.Response) (error, error) {
 			contentSample = contentSample[:100] + "..."
 		}
 
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, contentSample), nil
+		return newStatusError(resp.StatusCode, contentSample), nil
 	}
 
 	apiErr.StatusCode = resp.StatusCode
-	c.log(LogLevelInfo, "Parsed API error: %+v", apiErr)
+	c.logContext(responseContext(resp), LogLevelInfo, "Parsed API error: %+v", apiErr)
 
 	return &apiErr, nil
 }
 
+// newStatusError returns an APIError for an error response without parseable
+// error details, so that callers can still classify it by status code.
+func newStatusError(statusCode int, message string) *errors.APIError {
+	apiErr := &errors.APIError{StatusCode: statusCode, Message: message}
+	apiErr.Type = apiErr.GetType()
+	return apiErr
+}
+
 // decodeJSONResponse decodes a JSON response into the provided result.
 func (c *Client) decodeJSONResponse(resp *http.Response, result interface{}) error {
 	// Save the response body for logging if needed
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		c.log(LogLevelError, "Failed to read response body: %v", err)
+		c.logContext(responseContext(resp), LogLevelError, "Failed to read response body: %v", err)
 		return fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Log the raw response body
-	c.log(LogLevelDebug, "Response body: %s", string(body))
+	c.logContext(responseContext(resp), LogLevelDebug, "Response body: %s", c.debugBody(body, resp.Header.Get("Content-Type")))
 
 	// Restore the response body
 	resp.Body = io.NopCloser(bytes.NewBuffer(body))
 
+	return c.decodeJSONBody(responseContext(resp), body, result)
+}
+
+// decodeJSONBody decodes a JSON response body into the provided result.
+func (c *Client) decodeJSONBody(ctx context.Context, body []byte, result interface{}) error {
+	// An empty body, as sent with 202 and 204 responses, leaves result unchanged
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
 	// Try to unmarshal the response
 	if err := json.Unmarshal(body, result); err != nil {
-		c.log(LogLevelError, "Failed to unmarshal response: %v", err)
+		c.logContext(ctx, LogLevelError, "Failed to unmarshal response: %v", err)
 
 		switch {
 		case err.Error() == "unexpected end of JSON input":
-			c.log(LogLevelError, "JSON is incomplete or empty")
+			c.logContext(ctx, LogLevelError, "JSON is incomplete or empty")
 		case err.Error() == "invalid character '\\'' looking for beginning of value":
-			c.log(LogLevelError, "Response is not valid JSON, might be plain text or HTML")
+			c.logContext(ctx, LogLevelError, "Response is not valid JSON, might be plain text or HTML")
 		case err.Error() == "invalid character '<' looking for beginning of value":
-			c.log(LogLevelError, "Response is likely HTML instead of JSON")
+			c.logContext(ctx, LogLevelError, "Response is likely HTML instead of JSON")
 		}
 
 		if err, ok := err.(*json.SyntaxError); ok {
-			c.log(LogLevelError, "JSON syntax error at offset %d: %v", err.Offset, err)
+			c.logContext(ctx, LogLevelError, "JSON syntax error at offset %d: %v", err.Offset, err)
 			// Print the part of the JSON that caused the error
 			if int(err.Offset) < len(body) {
 				start := int(err.Offset) - 20
@@ -431,13 +676,13 @@ func (c *Client) decodeJSONResponse(resp *http.Response, result interface{}) err
 				if end > len(body) {
 					end = len(body)
 				}
-				c.log(LogLevelError, "Error context: ...%s...", string(body[start:end]))
+				c.logContext(ctx, LogLevelError, "Error context: ...%s...", string(body[start:end]))
 			}
 		}
 
 		// Show expected structure of result
 		resultType := fmt.Sprintf("%T", result)
-		c.log(LogLevelDebug, "Expected to unmarshal into type: %s", resultType)
+		c.logContext(ctx, LogLevelDebug, "Expected to unmarshal into type: %s", resultType)
 
 		return fmt.Errorf("failed to unmarshal response: %w", err)
 	}
@@ -447,9 +692,15 @@ func (c *Client) decodeJSONResponse(resp *http.Response, result interface{}) err
 
 // Get sends a GET request to the Apple Music API.
 func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
-	c.log(LogLevelInfo, "Making GET request to %s", path)
+	_, err := c.GetConditional(ctx, path, result)
+	return err
+}
+
+// Post sends a POST request to the Apple Music API.
+func (c *Client) Post(ctx context.Context, path string, body, result interface{}) error {
+	c.logContext(ctx, LogLevelInfo, "Making POST request to %s", path)
 
-	req, err := c.NewRequest(ctx, "GET", path, nil)
+	req, err := c.NewRequest(ctx, "POST", path, body)
 	if err != nil {
 		return err
 	}
@@ -463,15 +714,19 @@ func (c *Client) Get(ctx context.Context, path string, result interface{}) error
 	return c.decodeJSONResponse(resp, result)
 }
 
-// Post sends a POST request to the Apple Music API.
-func (c *Client) Post(ctx context.Context, path string, body, result interface{}) error {
-	c.log(LogLevelInfo, "Making POST request to %s", path)
+// PostWithHeaders sends a POST request with additional headers for this request only.
+func (c *Client) PostWithHeaders(ctx context.Context, path string, body interface{}, headers map[string]string, result interface{}) error {
+	c.logContext(ctx, LogLevelInfo, "Making POST request to %s", path)
 
 	req, err := c.NewRequest(ctx, "POST", path, body)
 	if err != nil {
 		return err
 	}
 
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
 	resp, err := c.Do(req)
 	if err != nil {
 		return err
@@ -483,7 +738,7 @@ func (c *Client) Post(ctx context.Context, path string, body, result interface{}
 
 // Put sends a PUT request to the Apple Music API.
 func (c *Client) Put(ctx context.Context, path string, body, result interface{}) error {
-	c.log(LogLevelInfo, "Making PUT request to %s", path)
+	c.logContext(ctx, LogLevelInfo, "Making PUT request to %s", path)
 
 	req, err := c.NewRequest(ctx, "PUT", path, body)
 	if err != nil {
@@ -501,7 +756,7 @@ func (c *Client) Put(ctx context.Context, path string, body, result interface{})
 
 // Delete sends a DELETE request to the Apple Music API.
 func (c *Client) Delete(ctx context.Context, path string, result interface{}) error {
-	c.log(LogLevelInfo, "Making DELETE request to %s", path)
+	c.logContext(ctx, LogLevelInfo, "Making DELETE request to %s", path)
 
 	req, err := c.NewRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -516,3 +771,47 @@ func (c *Client) Delete(ctx context.Context, path string, result interface{}) er
 
 	return c.decodeJSONResponse(resp, result)
 }
+
+// Download gets the resource at an absolute URL outside the API, such as an
+// artwork image, with the client's HTTP client and without the API's
+// authorization headers. It returns the body and its content type, or an error
+// if the response is not successful or the body exceeds maxBytes; a maxBytes
+// of zero or less means no limit.
+func (c *Client) Download(ctx context.Context, rawURL string, maxBytes int64) ([]byte, string, error) {
+	c.logContext(ctx, LogLevelInfo, "Downloading %s", rawURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+	defer drainAndClose(resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("failed to download %s: %s", rawURL, resp.Status)
+	}
+
+	body := io.Reader(resp.Body)
+	if maxBytes > 0 {
+		if resp.ContentLength > maxBytes {
+			return nil, "", fmt.Errorf("download of %s exceeds %d bytes", rawURL, maxBytes)
+		}
+		body = io.LimitReader(resp.Body, maxBytes+1)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read download of %s: %w", rawURL, err)
+	}
+
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, "", fmt.Errorf("download of %s exceeds %d bytes", rawURL, maxBytes)
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
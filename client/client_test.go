@@ -0,0 +1,411 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/errors"
+)
+
+// recordingServer is a test server that records the requests it receives and
+// answers them with handler.
+type recordingServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+// newRecordingServer starts a recordingServer that answers every request with
+// an empty JSON document unless handler is set, and a client pointed at it
+// with a developer token set.
+func newRecordingServer(t *testing.T, handler http.HandlerFunc, options ...ClientOption) (*recordingServer, *Client) {
+	t.Helper()
+
+	server := &recordingServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		server.mu.Lock()
+		server.requests = append(server.requests, r.Clone(context.Background()))
+		server.bodies = append(server.bodies, body)
+		server.mu.Unlock()
+
+		if handler != nil {
+			handler(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(append([]ClientOption{WithBaseURL(server.URL)}, options...)...)
+	c.SetDeveloperToken("developer-token")
+	return server, c
+}
+
+// Requests returns the requests the server has received, in order.
+func (s *recordingServer) Requests() []*http.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*http.Request(nil), s.requests...)
+}
+
+// Bodies returns the bodies of the requests the server has received, in order.
+func (s *recordingServer) Bodies() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]byte(nil), s.bodies...)
+}
+
+func TestCustomUserTokenHeader(t *testing.T) {
+	server, c := newRecordingServer(t, nil, WithUserTokenHeader("X-Gateway-User-Token"))
+	c.SetUserToken("user-token")
+
+	var response interface{}
+	if err := c.Get(context.Background(), "me/library/songs", &response); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	header := server.Requests()[0].Header
+	if got := header.Get("X-Gateway-User-Token"); got != "user-token" {
+		t.Errorf("X-Gateway-User-Token = %q, want %q", got, "user-token")
+	}
+	if got := header.Get(DefaultUserTokenHeader); got != "" {
+		t.Errorf("%s = %q, want it unset", DefaultUserTokenHeader, got)
+	}
+}
+
+func TestMissingCustomUserTokenHeaderError(t *testing.T) {
+	_, c := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}, WithUserTokenHeader("X-Gateway-User-Token"))
+
+	var response interface{}
+	err := c.Get(context.Background(), "catalog/us/library/songs", &response)
+	if err == nil {
+		t.Fatal("Get() error = nil, want an authentication error")
+	}
+	if !strings.Contains(err.Error(), "X-Gateway-User-Token is required") {
+		t.Errorf("Get() error = %q, want it to name X-Gateway-User-Token", err)
+	}
+}
+
+func TestContextUserTokensConcurrently(t *testing.T) {
+	_, c := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":[{"id":%q}]}`, r.Header.Get(DefaultUserTokenHeader))
+	})
+	c.SetUserToken("shared-token")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(token string) {
+			defer wg.Done()
+
+			var response struct {
+				Data []struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			if err := c.Get(WithUserToken(context.Background(), token), "me/library/songs", &response); err != nil {
+				errs <- err
+				return
+			}
+			if len(response.Data) != 1 || response.Data[0].ID != token {
+				errs <- fmt.Errorf("request with %s sent user token %+v", token, response.Data)
+			}
+		}(fmt.Sprintf("user-token-%d", i))
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestDoResendsRequestBody(t *testing.T) {
+	server, c := newRecordingServer(t, nil)
+	c.SetUserToken("user-token")
+
+	req, err := c.NewRequest(context.Background(), "POST", "me/library/playlists", map[string]string{"name": "Mock"})
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatalf("Do() #%d error = %v", i+1, err)
+		}
+		resp.Body.Close()
+	}
+
+	bodies := server.Bodies()
+	if len(bodies) != 2 {
+		t.Fatalf("got %d requests, want 2", len(bodies))
+	}
+	if want := "{\"name\":\"Mock\"}\n"; string(bodies[0]) != want || string(bodies[1]) != want {
+		t.Errorf("bodies = %q, %q, want %q twice", bodies[0], bodies[1], want)
+	}
+}
+
+func TestContextHeaders(t *testing.T) {
+	server, c := newRecordingServer(t, nil)
+	c.SetUserToken("user-token")
+
+	ctx := WithHeaders(context.Background(), map[string]string{
+		"X-Trace":          "trace-1",
+		"Authorization":    "Bearer stolen",
+		"music-user-token": "other-user",
+		"Accept":           "application/vnd.api+json",
+	})
+
+	var response interface{}
+	if err := c.Get(ctx, "me/library/songs", &response); err != nil {
+		t.Fatalf("Get() with headers error = %v", err)
+	}
+	if err := c.Get(context.Background(), "me/library/songs", &response); err != nil {
+		t.Fatalf("Get() without headers error = %v", err)
+	}
+
+	requests := server.Requests()
+	withHeaders, without := requests[0].Header, requests[1].Header
+
+	if got := withHeaders.Get("X-Trace"); got != "trace-1" {
+		t.Errorf("X-Trace = %q, want %q", got, "trace-1")
+	}
+	if got := withHeaders.Get("Accept"); got != "application/vnd.api+json" {
+		t.Errorf("Accept = %q, want the per-request value", got)
+	}
+	if got := withHeaders.Get("Authorization"); got != "Bearer developer-token" {
+		t.Errorf("Authorization = %q, want the developer token", got)
+	}
+	if got := withHeaders.Get(DefaultUserTokenHeader); got != "user-token" {
+		t.Errorf("%s = %q, want the client's user token", DefaultUserTokenHeader, got)
+	}
+
+	if got := without.Get("X-Trace"); got != "" {
+		t.Errorf("X-Trace leaked into a request without headers: %q", got)
+	}
+	if got := without.Get("Accept"); got != "application/json" {
+		t.Errorf("Accept = %q without headers, want %q", got, "application/json")
+	}
+}
+
+// trackedBody is a response body that records whether it was read to the end
+// and closed.
+type trackedBody struct {
+	io.ReadCloser
+	eof, closed bool
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.eof = true
+	}
+	return n, err
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return b.ReadCloser.Close()
+}
+
+func TestErrorResponsesAreDrained(t *testing.T) {
+	body := `{"errors":[{"status":"401","title":"Unauthorized","detail":"` + strings.Repeat("x", 16<<10) + `"}]}`
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "/library/") {
+			w.WriteHeader(http.StatusUnauthorized)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		w.Write([]byte(body))
+	}))
+
+	var mu sync.Mutex
+	connections := 0
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			connections++
+			mu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	var bodies []*trackedBody
+	transport := &http.Transport{}
+	defer transport.CloseIdleConnections()
+
+	c := NewClient(WithBaseURL(server.URL), WithHTTPClient(&http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := transport.RoundTrip(req)
+			if err == nil {
+				body := &trackedBody{ReadCloser: resp.Body}
+				bodies = append(bodies, body)
+				resp.Body = body
+			}
+			return resp, err
+		}),
+	}))
+	c.SetDeveloperToken("developer-token")
+
+	for i := 0; i < 20; i++ {
+		path := "catalog/us/songs/1"
+		if i%2 == 0 {
+			// Answered with a 401 that the client reports without reading the body
+			path = "catalog/us/library/songs"
+		}
+
+		var response interface{}
+		if err := c.Get(context.Background(), path, &response); err == nil {
+			t.Fatalf("Get(%s) #%d error = nil, want an API error", path, i+1)
+		}
+	}
+
+	for i, body := range bodies {
+		if !body.eof || !body.closed {
+			t.Errorf("response #%d body read to the end = %t, closed = %t, want both", i+1, body.eof, body.closed)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if connections != 1 {
+		t.Errorf("20 error responses opened %d connections, want 1", connections)
+	}
+}
+
+func TestContentTypeOnlyWithBody(t *testing.T) {
+	server, c := newRecordingServer(t, nil)
+	c.SetUserToken("user-token")
+
+	var response interface{}
+	if err := c.Get(context.Background(), "catalog/us/songs/1", &response); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := c.Post(context.Background(), "me/library/playlists", map[string]string{"name": "Mock"}, &response); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+
+	requests := server.Requests()
+	if got, ok := requests[0].Header["Content-Type"]; ok {
+		t.Errorf("GET Content-Type = %q, want it unset", got)
+	}
+	if got := requests[1].Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("POST Content-Type = %q, want %q", got, "application/json")
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"catalog/us/songs/1", "catalog/us/songs/1"},
+		{"/catalog/us/songs/1/", "catalog/us/songs/1"},
+		{"catalog//us///songs/1", "catalog/us/songs/1"},
+		{"//me/library", "me/library"},
+		{"catalog/us/search?term=a//b", "catalog/us/search?term=a//b"},
+		{"catalog//us/songs/?ids=1", "catalog/us/songs?ids=1"},
+		{"//", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizePath(tt.path); got != tt.want {
+			t.Errorf("normalizePath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	c := NewClient(WithBaseURL("https://api.music.apple.com/"), WithAPIVersion("/v1/"))
+	if got, want := c.buildURL("//catalog//us/songs"), "https://api.music.apple.com/v1/catalog/us/songs"; got != want {
+		t.Errorf("buildURL() = %q, want %q", got, want)
+	}
+}
+
+func TestDeveloperTokenHintOnlyWithoutUserToken(t *testing.T) {
+	_, c := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":[{"status":"401","title":"Unauthorized"}]}`))
+	})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		path     string
+		wantHint bool
+	}{
+		{"catalog request", context.Background(), "catalog/us/songs/1", true},
+		{"user token sent", WithUserToken(context.Background(), "expired-user-token"), "me/library/songs", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response interface{}
+			err := c.Get(tt.ctx, tt.path, &response)
+
+			var apiErr *errors.APIError
+			if !stderrors.As(err, &apiErr) {
+				t.Fatalf("Get() error = %v, want an *errors.APIError", err)
+			}
+			if got := apiErr.Hint == developerTokenHint; got != tt.wantHint {
+				t.Errorf("developer token hint attached = %v, want %v (error %q)", got, tt.wantHint, err)
+			}
+		})
+	}
+}
+
+func TestUnauthorizedErrorsAreClassified(t *testing.T) {
+	server, c := newRecordingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	tests := []struct {
+		name           string
+		developerToken string
+		path           string
+	}{
+		{"developer token rejected", "developer-token", "catalog/us/songs/1"},
+		{"user token missing", "developer-token", "catalog/us/library/songs"},
+		{"developer token missing", "", "catalog/us/songs/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c.SetDeveloperToken(tt.developerToken)
+
+			var response interface{}
+			err := c.Get(context.Background(), tt.path, &response)
+			if !errors.IsAuthenticationError(err) {
+				t.Errorf("IsAuthenticationError(%v) = false, want true", err)
+			}
+			if wrapped := fmt.Errorf("ping: %w", err); !errors.IsAuthenticationError(wrapped) {
+				t.Errorf("IsAuthenticationError(%v) = false for a wrapped error, want true", wrapped)
+			}
+		})
+	}
+
+	if requests := server.Requests(); len(requests) != len(tests) {
+		t.Errorf("got %d requests, want %d", len(requests), len(tests))
+	}
+}
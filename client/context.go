@@ -0,0 +1,74 @@
+package client
+
+import "context"
+
+// contextKey is the type of context keys defined by this package.
+type contextKey int
+
+const (
+	// logUserIDKey is the context key for the log user ID.
+	logUserIDKey contextKey = iota
+
+	// userTokenKey is the context key for a per-request user token.
+	userTokenKey
+
+	// headersKey is the context key for per-request headers.
+	headersKey
+
+	// requestIDKey is the context key for the ID of a request.
+	requestIDKey
+)
+
+// WithLogUserID returns a copy of ctx carrying a logical user ID that the
+// client includes in its log lines for requests made with it. The ID is never
+// sent to the Apple Music API.
+func WithLogUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, logUserIDKey, userID)
+}
+
+// LogUserID returns the log user ID attached to ctx with WithLogUserID, or an
+// empty string if there is none.
+func LogUserID(ctx context.Context) string {
+	userID, _ := ctx.Value(logUserIDKey).(string)
+	return userID
+}
+
+// WithUserToken returns a copy of ctx carrying a user token that the client
+// sends with requests made with it, in place of the token set with
+// SetUserToken. This lets a single client make requests on behalf of different
+// users concurrently.
+func WithUserToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, userTokenKey, token)
+}
+
+// UserToken returns the user token attached to ctx with WithUserToken, or an
+// empty string if there is none.
+func UserToken(ctx context.Context) string {
+	token, _ := ctx.Value(userTokenKey).(string)
+	return token
+}
+
+// WithHeaders returns a copy of ctx carrying headers that the client adds to
+// requests made with it. Headers are applied in this order, later ones
+// replacing earlier ones with the same name: the client's default headers,
+// such as Accept, then headers set with WithHeader, then these per-request
+// headers. Per-request headers never replace the Authorization or user token
+// headers. Calling WithHeaders again replaces the headers attached to ctx.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return context.WithValue(ctx, headersKey, headers)
+}
+
+// Headers returns the per-request headers attached to ctx with WithHeaders, or
+// nil if there are none.
+func Headers(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(headersKey).(map[string]string)
+	return headers
+}
+
+// RequestID returns the client-generated ID of the request that ctx belongs
+// to, such as the context of a response's request, or an empty string if
+// request IDs are not enabled.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
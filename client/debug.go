@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultDebugBodyLimit is the default maximum number of bytes of a request or
+// response body written to debug logs.
+const DefaultDebugBodyLimit = 4096
+
+// WithDebugBodyLimit sets the maximum number of bytes of a body written to
+// debug logs. See SetDebugBodyLimit.
+func WithDebugBodyLimit(n int) ClientOption {
+	return func(c *Client) {
+		c.SetDebugBodyLimit(n)
+	}
+}
+
+// SetDebugBodyLimit sets the maximum number of bytes of a request or response
+// body written to debug logs; longer bodies are truncated. A limit of zero or
+// less logs bodies in full. Bodies with a non-text content type, such as audio
+// previews, are never logged, only their size. The default is
+// DefaultDebugBodyLimit.
+func (c *Client) SetDebugBodyLimit(n int) {
+	c.debugBodyLimit = n
+}
+
+// debugBody returns body as it should appear in debug logs: omitted if it is
+// not text, and truncated to the debug body limit.
+func (c *Client) debugBody(body []byte, contentType string) string {
+	if !isTextContent(contentType, body) {
+		return fmt.Sprintf("[%d bytes of %s omitted]", len(body), contentType)
+	}
+
+	if c.debugBodyLimit > 0 && len(body) > c.debugBodyLimit {
+		return fmt.Sprintf("%s... [truncated, %d bytes total]", body[:c.debugBodyLimit], len(body))
+	}
+
+	return string(body)
+}
+
+// isTextContent reports whether a body with the given content type is text. A
+// body without a content type is text if it is valid UTF-8.
+func isTextContent(contentType string, body []byte) bool {
+	if contentType == "" {
+		return utf8.Valid(body)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		mediaType == "application/json",
+		mediaType == "application/xml",
+		mediaType == "application/javascript",
+		mediaType == "application/x-www-form-urlencoded",
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDebugBody(t *testing.T) {
+	large := strings.Repeat("a", DefaultDebugBodyLimit+100)
+
+	tests := []struct {
+		name        string
+		limit       int
+		body        string
+		contentType string
+		want        string
+	}{
+		{"short", DefaultDebugBodyLimit, `{"data":[]}`, "application/json", `{"data":[]}`},
+		{"large", DefaultDebugBodyLimit, large, "application/json; charset=utf-8",
+			large[:DefaultDebugBodyLimit] + "... [truncated, 4196 bytes total]"},
+		{"exactly the limit", 4, "abcd", "text/plain", "abcd"},
+		{"unlimited", 0, large, "application/json", large},
+		{"binary", DefaultDebugBodyLimit, "\x00\x01", "audio/mp4", "[2 bytes of audio/mp4 omitted]"},
+		{"no content type", DefaultDebugBodyLimit, "plain", "", "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(WithDebugBodyLimit(tt.limit))
+			if got := c.debugBody([]byte(tt.body), tt.contentType); got != tt.want {
+				t.Errorf("debugBody() = %.80q, want %.80q", got, tt.want)
+			}
+		})
+	}
+}
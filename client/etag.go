@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// etagEntry is a cached response body and the ETag it was served with.
+type etagEntry struct {
+	etag string
+	body []byte
+}
+
+// etagCache stores the last ETag and response body per request URL and user.
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+// WithETagCache enables conditional GET requests. See SetETagCaching.
+func WithETagCache() ClientOption {
+	return func(c *Client) {
+		c.SetETagCaching(true)
+	}
+}
+
+// SetETagCaching enables or disables conditional GET requests. When enabled,
+// the client remembers the ETag and body of each GET response per URL and
+// user token, sends If-None-Match on the next request for the same URL, and
+// decodes the remembered body when the API answers 304 Not Modified. The cache
+// is held in memory for the lifetime of the client; disabling it discards it.
+func (c *Client) SetETagCaching(enabled bool) {
+	if !enabled {
+		c.etags = nil
+		return
+	}
+
+	if c.etags == nil {
+		c.etags = &etagCache{entries: make(map[string]etagEntry)}
+	}
+}
+
+// GetConditional sends a GET request like Get and reports whether the result
+// was decoded from the ETag cache after a 304 Not Modified response. It always
+// reports false when ETag caching is disabled.
+func (c *Client) GetConditional(ctx context.Context, path string, result interface{}) (fromCache bool, err error) {
+	c.logContext(ctx, LogLevelInfo, "Making GET request to %s", path)
+
+	req, err := c.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return false, err
+	}
+
+	cache := c.etags
+	key := req.URL.String() + "\x00" + c.userTokenFor(ctx)
+
+	var cached etagEntry
+	var hasCached bool
+	if cache != nil {
+		cache.mu.Lock()
+		cached, hasCached = cache.entries[key]
+		cache.mu.Unlock()
+
+		if hasCached {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		c.logContext(ctx, LogLevelInfo, "Not modified, using cached response for %s", path)
+		return true, c.decodeJSONBody(ctx, cached.body, result)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logContext(ctx, LogLevelError, "Failed to read response body: %v", err)
+		return false, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	c.logContext(ctx, LogLevelDebug, "Response body: %s", c.debugBody(body, resp.Header.Get("Content-Type")))
+
+	if err := c.decodeJSONBody(ctx, body, result); err != nil {
+		return false, err
+	}
+
+	if etag := resp.Header.Get("ETag"); cache != nil && etag != "" {
+		cache.mu.Lock()
+		cache.entries[key] = etagEntry{etag: etag, body: body}
+		cache.mu.Unlock()
+	}
+
+	return false, nil
+}
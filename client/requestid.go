@@ -0,0 +1,36 @@
+package client
+
+import "context"
+
+// RequestIDHeader is the header used to send a client-generated request ID.
+const RequestIDHeader = "X-Request-ID"
+
+// WithRequestIDFunc makes the client attach a request ID, generated by fn, to
+// every request in the RequestIDHeader header and to its log lines. A nil fn
+// generates a UUID for each request.
+func WithRequestIDFunc(fn func() string) ClientOption {
+	return func(c *Client) {
+		c.SetRequestIDFunc(fn)
+	}
+}
+
+// SetRequestIDFunc enables request IDs, generated by fn, or by NewUUID if fn
+// is nil. See WithRequestIDFunc.
+func (c *Client) SetRequestIDFunc(fn func() string) {
+	if fn == nil {
+		fn = func() string {
+			id, _ := NewUUID()
+			return id
+		}
+	}
+	c.requestID = fn
+}
+
+// withRequestID returns ctx carrying a new request ID if request IDs are
+// enabled and ctx does not already carry one, and ctx otherwise.
+func (c *Client) withRequestID(ctx context.Context) context.Context {
+	if c.requestID == nil || RequestID(ctx) != "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey, c.requestID())
+}
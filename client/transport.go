@@ -0,0 +1,98 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// SetForceHTTP1 makes the client use HTTP/1.1 instead of negotiating HTTP/2,
+// or restores HTTP/2 negotiation, which is the default.
+//
+// HTTP/2 multiplexes concurrent requests over a single connection and is
+// usually faster, but some proxies and middleboxes break long-lived HTTP/2
+// connections. Forcing HTTP/1.1 works around them at the cost of one
+// connection per concurrent request.
+//
+// It returns an error if the HTTP client uses a custom RoundTripper that is not
+// an *http.Transport. Set a custom HTTP client before calling it.
+func (c *Client) SetForceHTTP1(force bool) error {
+	transport, err := c.transport()
+	if err != nil {
+		return err
+	}
+
+	if force {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	} else {
+		transport.ForceAttemptHTTP2 = true
+		transport.TLSNextProto = nil
+	}
+
+	// Connections negotiated under the previous setting are not reused
+	transport.CloseIdleConnections()
+
+	return nil
+}
+
+// transport returns the client's *http.Transport so it can be configured. The
+// first time it is called, the transport is replaced with a clone, and the HTTP
+// client with a copy using it, so that changes never affect other clients
+// sharing the transport or HTTP client, such as one passed to WithHTTPClient
+// or http.DefaultTransport. A nil transport is cloned from
+// http.DefaultTransport.
+func (c *Client) transport() (*http.Transport, error) {
+	if c.ownTransport != nil && c.client.Transport == c.ownTransport {
+		return c.ownTransport, nil
+	}
+
+	var transport *http.Transport
+	switch t := c.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil, fmt.Errorf("cannot configure custom transport of type %T", c.client.Transport)
+	}
+
+	client := *c.client
+	client.Transport = transport
+	c.client = &client
+	c.ownTransport = transport
+	return transport, nil
+}
+
+// SetInsecureSkipVerify disables or restores verification of the server's TLS
+// certificate chain and host name, which is enabled by default.
+//
+// It is meant only for tests against a local HTTPS mock with a self-signed
+// certificate. NEVER enable it in production: without verification, anyone who
+// can intercept the connection can impersonate the Apple Music API and read the
+// developer and user tokens.
+//
+// It returns an error if the HTTP client uses a custom RoundTripper that is not
+// an *http.Transport. Set a custom HTTP client before calling it.
+func (c *Client) SetInsecureSkipVerify(skip bool) error {
+	transport, err := c.transport()
+	if err != nil {
+		return err
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	} else {
+		transport.TLSClientConfig = transport.TLSClientConfig.Clone()
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = skip
+
+	// Connections verified under the previous setting are not reused
+	transport.CloseIdleConnections()
+
+	if skip {
+		c.Logf(LogLevelError, "TLS certificate verification is disabled; never do this in production")
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetInsecureSkipVerifyDoesNotChangeSharedTransport(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	shared := &http.Transport{}
+	sharedClient := &http.Client{Transport: shared}
+
+	c := NewClient(WithBaseURL(server.URL), WithHTTPClient(sharedClient))
+	if err := c.SetInsecureSkipVerify(true); err != nil {
+		t.Fatalf("SetInsecureSkipVerify() error = %v", err)
+	}
+
+	if shared.TLSClientConfig != nil && shared.TLSClientConfig.InsecureSkipVerify {
+		t.Error("SetInsecureSkipVerify() changed the TLS config of the shared transport")
+	}
+	if sharedClient.Transport != shared {
+		t.Error("SetInsecureSkipVerify() replaced the transport of the shared HTTP client")
+	}
+
+	var response interface{}
+	if err := c.Get(context.Background(), "catalog/us/songs", &response); err != nil {
+		t.Errorf("Get() with verification disabled error = %v", err)
+	}
+
+	other := NewClient(WithBaseURL(server.URL), WithHTTPClient(sharedClient))
+	if err := other.Get(context.Background(), "catalog/us/songs", &response); err == nil {
+		t.Error("Get() on another client sharing the transport succeeded against a self-signed certificate")
+	}
+
+	if err := c.SetInsecureSkipVerify(false); err != nil {
+		t.Fatalf("SetInsecureSkipVerify(false) error = %v", err)
+	}
+	if err := c.Get(context.Background(), "catalog/us/songs", &response); err == nil {
+		t.Error("Get() with verification restored succeeded against a self-signed certificate")
+	}
+}
+
+func TestSetInsecureSkipVerifyRejectsCustomRoundTripper(t *testing.T) {
+	c := NewClient(WithHTTPClient(&http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}))
+
+	if err := c.SetInsecureSkipVerify(true); err == nil {
+		t.Error("SetInsecureSkipVerify() with a custom RoundTripper returned no error")
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper that is not an *http.Transport.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSetForceHTTP1DoesNotChangeSharedTransport(t *testing.T) {
+	shared := &http.Transport{ForceAttemptHTTP2: true}
+	sharedClient := &http.Client{Transport: shared}
+
+	c := NewClient(WithHTTPClient(sharedClient))
+	if err := c.SetForceHTTP1(true); err != nil {
+		t.Fatalf("SetForceHTTP1() error = %v", err)
+	}
+
+	if !shared.ForceAttemptHTTP2 {
+		t.Error("SetForceHTTP1() changed the shared transport")
+	}
+	if sharedClient.Transport != shared {
+		t.Error("SetForceHTTP1() replaced the transport of the shared HTTP client")
+	}
+
+	transport, ok := c.client.Transport.(*http.Transport)
+	if !ok || transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Errorf("client transport = %#v, want a clone forcing HTTP/1.1", c.client.Transport)
+	}
+
+	if err := c.SetForceHTTP1(false); err != nil {
+		t.Fatalf("SetForceHTTP1(false) error = %v", err)
+	}
+	if c.client.Transport != transport || !transport.ForceAttemptHTTP2 {
+		t.Error("SetForceHTTP1(false) did not restore HTTP/2 on the client's own transport")
+	}
+}
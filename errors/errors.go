@@ -2,10 +2,28 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
+var (
+	// ErrUserTokenRequired is returned before making a request that requires a
+	// user token, such as any request under me/, when none is set.
+	ErrUserTokenRequired = stderrors.New("user token is required")
+
+	// ErrUserTokenInvalid is returned when the user token is invalid, expired, or revoked.
+	ErrUserTokenInvalid = stderrors.New("user token is invalid or expired")
+
+	// ErrSubscriptionRequired is returned when the user has no active Apple Music subscription.
+	ErrSubscriptionRequired = stderrors.New("an active Apple Music subscription is required")
+
+	// ErrEmptyResponse is matched by the errors getters return when a request
+	// succeeded but the response contained no data, such as an unknown ID.
+	ErrEmptyResponse = stderrors.New("response contained no data")
+)
+
 // ErrorType represents the type of error.
 type ErrorType string
 
@@ -37,28 +55,100 @@ type APIError struct {
 	// Error message
 	Message string `json:"-"`
 
+	// Hint describes a likely cause of the error, when one is known
+	Hint string `json:"-"`
+
 	// Error details from the API
-	Errors []struct {
-		ID     string `json:"id"`
-		Title  string `json:"title"`
-		Detail string `json:"detail"`
-		Status string `json:"status"`
-		Code   string `json:"code"`
-	} `json:"errors"`
+	Errors []ErrorDetail `json:"errors"`
+}
+
+// ErrorDetail represents a single error object returned by the Apple Music API.
+type ErrorDetail struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+	Status string `json:"status"`
+	Code   string `json:"code"`
+}
+
+// PartialError is returned when a request for multiple items succeeded overall
+// but the response reported errors for some of the items.
+type PartialError struct {
+	// Errors reported for individual items
+	Errors []ErrorDetail
+}
+
+// Error returns the error message.
+func (e *PartialError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", err.Title, err.Detail))
+	}
+	return fmt.Sprintf("partial failure: %d item(s) failed: %s", len(e.Errors), strings.Join(messages, "; "))
 }
 
 // Error returns the error message.
 func (e *APIError) Error() string {
+	message := fmt.Sprintf("API error (status code: %d)", e.StatusCode)
+
+	if len(e.Errors) > 0 {
+		var messages []string
+		for _, err := range e.Errors {
+			messages = append(messages, fmt.Sprintf("%s: %s", err.Title, err.Detail))
+		}
+		message = fmt.Sprintf("%s: %s", message, strings.Join(messages, "; "))
+	} else if e.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, e.Message)
+	}
+
+	if e.Hint != "" {
+		message = fmt.Sprintf("%s (hint: %s)", message, e.Hint)
+	}
+
+	return message
+}
+
+// FirstCode returns the Apple error code of the first error detail, such as
+// "40300", or an empty string if the response carried no error details.
+func (e *APIError) FirstCode() string {
 	if len(e.Errors) == 0 {
-		return fmt.Sprintf("API error (status code: %d)", e.StatusCode)
+		return ""
 	}
+	return e.Errors[0].Code
+}
 
-	var messages []string
+// HasCode reports whether any error detail carries the given Apple error code.
+// The same status code can carry different meanings distinguished only by the code.
+func (e *APIError) HasCode(code string) bool {
 	for _, err := range e.Errors {
-		messages = append(messages, fmt.Sprintf("%s: %s", err.Title, err.Detail))
+		if err.Code == code {
+			return true
+		}
 	}
+	return false
+}
 
-	return fmt.Sprintf("API error (status code: %d): %s", e.StatusCode, strings.Join(messages, "; "))
+// BatchError is returned by helpers that make one request per key, such as a
+// storefront or an ID, when some of the requests failed. Results for the other
+// keys are still returned alongside it.
+type BatchError struct {
+	// Errors by key
+	Errors map[string]error
+}
+
+// Error returns the error message.
+func (e *BatchError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, fmt.Sprintf("%s: %v", key, e.Errors[key]))
+	}
+	return fmt.Sprintf("%d request(s) failed: %s", len(e.Errors), strings.Join(messages, "; "))
 }
 
 // GetType returns the error type based on the status code.
@@ -78,34 +168,39 @@ func (e *APIError) GetType() ErrorType {
 }
 
 // IsAuthenticationError returns true if the error is an authentication error.
+// Like the other Is functions, it also matches errors that wrap an *APIError.
 func IsAuthenticationError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.GetType() == ErrorTypeAuthentication
-	}
-	return false
+	var apiErr *APIError
+	return stderrors.As(err, &apiErr) && apiErr.GetType() == ErrorTypeAuthentication
 }
 
 // IsInvalidRequestError returns true if the error is an invalid request error.
 func IsInvalidRequestError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.GetType() == ErrorTypeInvalidRequest
-	}
-	return false
+	var apiErr *APIError
+	return stderrors.As(err, &apiErr) && apiErr.GetType() == ErrorTypeInvalidRequest
 }
 
 // IsRateLimitError returns true if the error is a rate limit error.
 func IsRateLimitError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.GetType() == ErrorTypeRateLimit
-	}
-	return false
+	var apiErr *APIError
+	return stderrors.As(err, &apiErr) && apiErr.GetType() == ErrorTypeRateLimit
 }
 
 // IsServerError returns true if the error is a server error.
 func IsServerError(err error) bool {
-	if apiErr, ok := err.(*APIError); ok {
-		return apiErr.GetType() == ErrorTypeServer
-	}
-	return false
+	var apiErr *APIError
+	return stderrors.As(err, &apiErr) && apiErr.GetType() == ErrorTypeServer
 }
 
+// IsPartialError returns true if the error reports that some items of a
+// request failed while the request as a whole succeeded.
+func IsPartialError(err error) bool {
+	var partialErr *PartialError
+	return stderrors.As(err, &partialErr)
+}
+
+// IsEmptyResponse returns true if the error reports a successful response
+// that contained no data.
+func IsEmptyResponse(err error) bool {
+	return stderrors.Is(err, ErrEmptyResponse)
+}
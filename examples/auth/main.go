@@ -196,24 +196,21 @@ func demoUserPlaylists() {
 	for i, playlist := range playlists {
 		fmt.Printf("%d. %s (%d tracks)\n", i+1, playlist.Attributes.Name, playlist.Attributes.TrackCount)
 
-		// If we have relationships data, print a few tracks
-		if playlist.Relationships.Tracks.Data != nil && len(playlist.Relationships.Tracks.Data) > 0 {
+		// Included tracks carry their attributes, so print a few directly
+		tracks, err := playlist.Tracks()
+		if err == nil && len(tracks) > 0 {
 			fmt.Println("   Sample tracks:")
 			// Show up to 3 tracks as a preview
-			maxTracks := 3
-			if len(playlist.Relationships.Tracks.Data) < maxTracks {
-				maxTracks = len(playlist.Relationships.Tracks.Data)
+			if len(tracks) > 3 {
+				tracks = tracks[:3]
 			}
 
-			// Get the actual songs for this playlist
-			if maxTracks > 0 {
-				songs, err := client.Playlists.GetUserPlaylistTracks(ctx, playlist.ID)
-				if err == nil && len(songs) > 0 {
-					for j := 0; j < maxTracks; j++ {
-						if j < len(songs) {
-							fmt.Printf("   - %s by %s\n", songs[j].Attributes.Name, songs[j].Attributes.ArtistName)
-						}
-					}
+			for _, track := range tracks {
+				switch track := track.(type) {
+				case *models.Song:
+					fmt.Printf("   - %s by %s\n", track.Attributes.Name, track.Attributes.ArtistName)
+				case *models.MusicVideo:
+					fmt.Printf("   - %s by %s (video)\n", track.Attributes.Name, track.Attributes.ArtistName)
 				}
 			}
 		}
@@ -16,13 +16,15 @@ func main() {
 	teamID := os.Getenv("APPLE_TEAM_ID")
 	keyID := os.Getenv("APPLE_KEY_ID")
 	privateKey := os.Getenv("APPLE_PRIVATE_KEY")
+	musicID := os.Getenv("APPLE_MUSIC_ID")
 
 	// Create a new developer token with a 6-month expiration
-	developerToken, err := auth.NewDeveloperToken(
+	developerToken, err := auth.NewDeveloperTokenWithExpiry(
 		teamID,
 		keyID,
 		[]byte(privateKey),
-		time.Hour*24*180,
+		musicID,
+		time.Now().Add(time.Hour*24*180),
 	)
 	if err != nil {
 		log.Fatalf("Failed to create developer token: %v", err)
@@ -57,6 +59,14 @@ func main() {
 
 	fmt.Printf("Direct Preview URL for song ID %s: %s\n", anotherSongID, directPreviewURL)
 
+	// Example 3: Get the preview URL from a specific storefront
+	jpPreviewURL, err := client.Catalog.GetSongPreviewURLForStorefront(ctx, anotherSongID, "jp")
+	if err != nil {
+		fmt.Printf("No JP preview for song ID %s: %v\n", anotherSongID, err)
+	} else {
+		fmt.Printf("JP Preview URL for song ID %s: %s\n", anotherSongID, jpPreviewURL)
+	}
+
 	// Example of how to handle a song without previews
 	fmt.Println("\nHandling songs without previews:")
 	noPreviewSongID := "invalid-id" // This will likely fail, just for demo
@@ -0,0 +1,38 @@
+package mockapi
+
+// Canned resources used by DefaultFixtures.
+const (
+	songJSON = `{"id":"1","type":"songs","href":"/v1/catalog/us/songs/1","attributes":{` +
+		`"albumName":"Mock Album","artistName":"Mock Artist","artwork":{"width":3000,"height":3000,"url":"https://example.com/{w}x{h}bb.jpg"},` +
+		`"durationInMillis":180000,"genreNames":["Pop","Music"],"hasLyrics":true,"isrc":"USMOCK0000001","name":"Mock Song",` +
+		`"playParams":{"id":"1","kind":"song"},"previews":[{"url":"https://example.com/preview.m4a"}],` +
+		`"releaseDate":"2024-01-01","trackNumber":1,"url":"https://music.apple.com/us/song/1"},` +
+		`"relationships":{"albums":{"data":[{"id":"2","type":"albums"}]},"artists":{"data":[{"id":"3","type":"artists"}]}}}`
+
+	albumJSON = `{"id":"2","type":"albums","href":"/v1/catalog/us/albums/2","attributes":{` +
+		`"artistName":"Mock Artist","artwork":{"width":3000,"height":3000,"url":"https://example.com/{w}x{h}bb.jpg"},` +
+		`"genreNames":["Pop","Music"],"isComplete":true,"name":"Mock Album","playParams":{"id":"2","kind":"album"},` +
+		`"releaseDate":"2024-01-01","trackCount":1,"upc":"000000000002","url":"https://music.apple.com/us/album/2"},` +
+		`"relationships":{"tracks":{"data":[` + songJSON + `]}}}`
+
+	artistJSON = `{"id":"3","type":"artists","href":"/v1/catalog/us/artists/3","attributes":{` +
+		`"genreNames":["Pop"],"name":"Mock Artist","url":"https://music.apple.com/us/artist/3"}}`
+)
+
+// DefaultFixtures returns canned catalog responses for a song ("1"), an album
+// ("2"), an artist ("3"), and a search in the "us" storefront, each of which
+// can be overridden per route.
+func DefaultFixtures() Fixtures {
+	return Fixtures{
+		"GET catalog/us/songs/1":   {Body: `{"data":[` + songJSON + `]}`},
+		"GET catalog/us/songs":     {Body: `{"data":[` + songJSON + `]}`},
+		"GET catalog/us/albums/2":  {Body: `{"data":[` + albumJSON + `]}`},
+		"GET catalog/us/albums":    {Body: `{"data":[` + albumJSON + `]}`},
+		"GET catalog/us/artists/3": {Body: `{"data":[` + artistJSON + `]}`},
+		"GET catalog/us/artists":   {Body: `{"data":[` + artistJSON + `]}`},
+		"GET catalog/us/search": {Body: `{"results":{` +
+			`"songs":{"href":"/v1/catalog/us/search?term=mock&types=songs","data":[` + songJSON + `]},` +
+			`"albums":{"href":"/v1/catalog/us/search?term=mock&types=albums","data":[` + albumJSON + `]},` +
+			`"artists":{"href":"/v1/catalog/us/search?term=mock&types=artists","data":[` + artistJSON + `]}}}`},
+	}
+}
@@ -0,0 +1,200 @@
+// Package mockapi provides an in-process stub of the Apple Music API for tests.
+//
+// A Server answers requests from canned responses keyed by route, so services
+// can be exercised without network access or credentials:
+//
+//	server := mockapi.NewServer(mockapi.DefaultFixtures())
+//	defer server.Close()
+//
+//	c := client.NewClient(client.WithBaseURL(server.URL))
+//	catalog := services.NewCatalogService(c)
+//	song, err := catalog.GetSong(ctx, "1")
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Response is a canned response.
+type Response struct {
+	// The HTTP status code. Zero means http.StatusOK.
+	Status int
+
+	// The response body.
+	Body string
+}
+
+// Fixtures maps routes to canned responses. A route is an HTTP method followed
+// by a space and a path relative to the API version, such as
+// "GET catalog/us/songs/1", optionally with a query string. A route without a
+// method matches any method.
+type Fixtures map[string]Response
+
+// Request is a request received by a Server, recorded while it was served so
+// that it can be inspected after the handler has returned.
+type Request struct {
+	// The HTTP method, such as "GET".
+	Method string
+
+	// The request URL, with the path and query string as received.
+	URL *url.URL
+
+	// The request headers.
+	Header http.Header
+
+	// The request body, empty if there was none.
+	Body []byte
+}
+
+// Server is a stub Apple Music API server. Use its URL with client.WithBaseURL.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   Fixtures
+	requests []Request
+}
+
+// NewServer starts a Server that answers with the given fixtures.
+func NewServer(fixtures Fixtures) *Server {
+	s := &Server{routes: make(Fixtures, len(fixtures))}
+	for route, response := range fixtures {
+		s.routes[route] = response
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Handle sets the response for a route, replacing any existing one.
+func (s *Server) Handle(route string, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[route] = Response{Status: status, Body: body}
+}
+
+// HandleJSON sets the response for a route to v encoded as JSON.
+func (s *Server) HandleJSON(route string, status int, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode response for %s: %w", route, err)
+	}
+
+	s.Handle(route, status, string(body))
+	return nil
+}
+
+// Requests returns the requests the server has received, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// serveHTTP answers a request from the matching fixture, or with an Apple
+// style 404 error if there is none.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	u := *r.URL
+	request := Request{Method: r.Method, URL: &u, Header: r.Header.Clone(), Body: body}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	response, ok := s.match(r)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"errors":[{"id":"mockapi","title":"Resource Not Found","detail":"no fixture for %s %s","status":"404","code":"40400"}]}`,
+			r.Method, strings.ReplaceAll(r.URL.RequestURI(), `"`, `\"`))
+		return
+	}
+
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	fmt.Fprint(w, response.Body)
+}
+
+// match finds the fixture for a request, preferring a route with the method
+// and query string, then with the method only, then without a method.
+func (s *Server) match(r *http.Request) (Response, bool) {
+	path := routePath(r.URL.Path)
+	candidates := []string{path}
+	if r.URL.RawQuery != "" {
+		candidates = []string{path + "?" + r.URL.RawQuery, path}
+	}
+
+	for _, candidate := range candidates {
+		if response, ok := s.routes[r.Method+" "+candidate]; ok {
+			return response, true
+		}
+		if response, ok := s.routes[candidate]; ok {
+			return response, true
+		}
+	}
+
+	return Response{}, false
+}
+
+// routePath strips the leading slash and API version from a request path.
+func routePath(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.IndexByte(path, '/'); i >= 0 && strings.HasPrefix(path, "v") {
+		path = path[i+1:]
+	}
+	return path
+}
+
+// LoadFixtures loads fixtures from the JSON files under dir. Each file answers
+// GET requests for the path of the file relative to dir, without the .json
+// extension, so dir/catalog/us/songs/1.json answers "GET catalog/us/songs/1".
+func LoadFixtures(dir string) (Fixtures, error) {
+	fixtures := make(Fixtures)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		route := strings.TrimSuffix(filepath.ToSlash(rel), ".json")
+		fixtures[http.MethodGet+" "+route] = Response{Body: string(body)}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures from %s: %w", dir, err)
+	}
+
+	return fixtures, nil
+}
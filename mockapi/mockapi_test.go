@@ -0,0 +1,162 @@
+package mockapi
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// send sends a request to the server and returns the status and body of the response.
+func send(t *testing.T, server *Server, method, path, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+
+	return resp.StatusCode, string(data)
+}
+
+func TestServerMatchesRoutes(t *testing.T) {
+	server := NewServer(Fixtures{
+		"GET catalog/us/songs/1":         {Body: `"method and path"`},
+		"GET catalog/us/songs?ids=1%2C2": {Body: `"method, path, and query"`},
+		"catalog/us/albums/2":            {Body: `"any method"`},
+		"POST me/library/playlists":      {Status: http.StatusCreated, Body: `"created"`},
+	})
+	defer server.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{"GET", "/v1/catalog/us/songs/1", http.StatusOK, `"method and path"`},
+		{"GET", "/v1/catalog/us/songs/1?l=en-US", http.StatusOK, `"method and path"`},
+		{"GET", "/v1/catalog/us/songs?ids=1%2C2", http.StatusOK, `"method, path, and query"`},
+		{"DELETE", "/v1/catalog/us/albums/2", http.StatusOK, `"any method"`},
+		{"POST", "/v1/me/library/playlists", http.StatusCreated, `"created"`},
+	}
+
+	for _, tt := range tests {
+		status, body := send(t, server, tt.method, tt.path, "")
+		if status != tt.status || body != tt.body {
+			t.Errorf("%s %s = %d %s, want %d %s", tt.method, tt.path, status, body, tt.status, tt.body)
+		}
+	}
+}
+
+func TestServerHandleOverridesFixture(t *testing.T) {
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+
+	server.Handle("GET catalog/us/songs/1", http.StatusServiceUnavailable, `{"errors":[]}`)
+	if status, body := send(t, server, "GET", "/v1/catalog/us/songs/1", ""); status != http.StatusServiceUnavailable || body != `{"errors":[]}` {
+		t.Errorf("overridden route = %d %s, want 503 with the new body", status, body)
+	}
+
+	if err := server.HandleJSON("GET catalog/us/albums/2", http.StatusOK, map[string]string{"id": "2"}); err != nil {
+		t.Fatalf("HandleJSON() error = %v", err)
+	}
+	if status, body := send(t, server, "GET", "/v1/catalog/us/albums/2", ""); status != http.StatusOK || body != `{"id":"2"}` {
+		t.Errorf("route set with HandleJSON = %d %s, want 200 {\"id\":\"2\"}", status, body)
+	}
+
+	if err := server.HandleJSON("GET catalog/us/albums/2", http.StatusOK, func() {}); err == nil {
+		t.Error("HandleJSON() with an unencodable value returned no error")
+	}
+}
+
+func TestServerNotFound(t *testing.T) {
+	server := NewServer(DefaultFixtures())
+	defer server.Close()
+
+	status, body := send(t, server, "GET", "/v1/catalog/us/songs/404", "")
+	if status != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", status, http.StatusNotFound)
+	}
+	if !strings.Contains(body, `"status":"404"`) || !strings.Contains(body, "no fixture for GET /v1/catalog/us/songs/404") {
+		t.Errorf("body = %s, want an Apple style 404 error naming the request", body)
+	}
+
+	if status, _ := send(t, server, "POST", "/v1/catalog/us/songs/1", ""); status != http.StatusNotFound {
+		t.Errorf("route registered for GET answered POST with %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestServerRecordsRequests(t *testing.T) {
+	server := NewServer(Fixtures{"POST me/library/playlists": {Status: http.StatusCreated}})
+	defer server.Close()
+
+	req, err := http.NewRequest("POST", server.URL+"/v1/me/library/playlists?l=en-US", strings.NewReader(`{"name":"Mock"}`))
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Music-User-Token", "user-token")
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	resp.Body.Close()
+
+	send(t, server, "GET", "/v1/catalog/us/songs/1", "")
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+
+	got := requests[0]
+	if got.Method != "POST" || got.URL.Path != "/v1/me/library/playlists" || got.URL.Query().Get("l") != "en-US" {
+		t.Errorf("request = %s %s, want POST /v1/me/library/playlists?l=en-US", got.Method, got.URL)
+	}
+	if got.Header.Get("Music-User-Token") != "user-token" {
+		t.Errorf("Music-User-Token header = %q, want %q", got.Header.Get("Music-User-Token"), "user-token")
+	}
+	if string(got.Body) != `{"name":"Mock"}` {
+		t.Errorf("body = %s, want {\"name\":\"Mock\"}", got.Body)
+	}
+
+	if requests[1].Method != "GET" || len(requests[1].Body) != 0 {
+		t.Errorf("second request = %s with %d body bytes, want GET without a body", requests[1].Method, len(requests[1].Body))
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "catalog", "us", "songs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "catalog", "us", "songs", "1.json"), []byte(`{"data":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a fixture"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures, err := LoadFixtures(dir)
+	if err != nil {
+		t.Fatalf("LoadFixtures() error = %v", err)
+	}
+
+	if len(fixtures) != 1 || fixtures["GET catalog/us/songs/1"].Body != `{"data":[]}` {
+		t.Errorf("LoadFixtures() = %v, want only GET catalog/us/songs/1", fixtures)
+	}
+}
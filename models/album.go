@@ -19,9 +19,18 @@ type AlbumAttributes struct {
 	// The artist name.
 	ArtistName string `json:"artistName"`
 
+	// The URL of the artist, when requested with extend.
+	ArtistURL string `json:"artistUrl,omitempty"`
+
 	// The album artwork.
 	Artwork Artwork `json:"artwork"`
 
+	// The audio traits, such as "lossless" or "atmos".
+	AudioTraits []string `json:"audioTraits,omitempty"`
+
+	// The audio variants, when requested with extend.
+	AudioVariants []string `json:"audioVariants,omitempty"`
+
 	// The content rating.
 	ContentRating string `json:"contentRating,omitempty"`
 
@@ -31,6 +40,9 @@ type AlbumAttributes struct {
 	// The editorial notes.
 	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
 
+	// The motion artwork, when requested with extend.
+	EditorialVideo *EditorialVideo `json:"editorialVideo,omitempty"`
+
 	// The genre names.
 	GenreNames []string `json:"genreNames"`
 
@@ -43,6 +55,9 @@ type AlbumAttributes struct {
 	// Whether the album is a single.
 	IsSingle bool `json:"isSingle"`
 
+	// Whether the album is Mastered for iTunes.
+	IsMasteredForItunes bool `json:"isMasteredForItunes,omitempty"`
+
 	// The name of the album.
 	Name string `json:"name"`
 
@@ -95,9 +110,53 @@ type AlbumsResponse struct {
 	Next string `json:"next,omitempty"`
 }
 
+// Pagination returns the pagination information from the response's meta and next link.
+func (r *AlbumsResponse) Pagination() Pagination {
+	return PaginationFromMeta(r.Meta, r.Next)
+}
+
 // GetArtworkURL returns the URL for the album artwork with the specified dimensions.
 func (a *Album) GetArtworkURL(width, height int) string {
-	return a.Attributes.Artwork.URL
+	return a.Attributes.Artwork.URLForSize(width, height)
+}
+
+// ArtistIDs returns the IDs of the album's artists from its artists
+// relationship, which is empty if the relationship was not returned.
+func (a *Album) ArtistIDs() []string {
+	return a.Relationships.Artists.IDs()
+}
+
+// HasUPC reports whether the album has a UPC.
+func (a *Album) HasUPC() bool {
+	return a.Attributes.UPC != ""
+}
+
+// IsValidUPC reports whether upc is in the format of a Universal Product Code
+// or the longer EAN and GTIN codes used for albums: 12 to 14 digits. The check
+// digit is not verified.
+func IsValidUPC(upc string) bool {
+	if len(upc) < 12 || len(upc) > 14 {
+		return false
+	}
+
+	for _, r := range upc {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Rating returns the album's content rating.
+func (a *Album) Rating() ContentRating {
+	return ParseContentRating(a.Attributes.ContentRating)
+}
+
+// IsAvailable reports whether the album is playable in the storefront it was
+// fetched from. Albums unavailable in a storefront may be returned as
+// placeholders without a name or play parameters.
+func (a *Album) IsAvailable() bool {
+	return a.Attributes.Name != "" && a.Attributes.PlayParams.ID != ""
 }
 
 // FormatReleaseDate formats the release date as a time.Time.
@@ -105,3 +164,15 @@ func (a *Album) FormatReleaseDate() (time.Time, error) {
 	return time.Parse("2006-01-02", a.Attributes.ReleaseDate)
 }
 
+// SupportsLossless reports whether the album is available in lossless or
+// high-resolution lossless audio.
+func (a *Album) SupportsLossless() bool {
+	return hasAudioQuality(a.Attributes.AudioTraits, a.Attributes.AudioVariants,
+		AudioTraitLossless, AudioTraitHiResLossless)
+}
+
+// SupportsAtmos reports whether the album is available in Dolby Atmos.
+func (a *Album) SupportsAtmos() bool {
+	return hasAudioQuality(a.Attributes.AudioTraits, a.Attributes.AudioVariants,
+		AudioTraitAtmos, AudioVariantDolbyAtmos)
+}
@@ -0,0 +1,25 @@
+package models
+
+import "testing"
+
+func TestIsValidUPC(t *testing.T) {
+	tests := []struct {
+		upc  string
+		want bool
+	}{
+		{"000000000002", true},
+		{"0602557382594", true},
+		{"00602557382594", true},
+		{"00000000002", false},
+		{"000006025573825940", false},
+		{"06025573825A4", false},
+		{"0602557-382594", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidUPC(tt.upc); got != tt.want {
+			t.Errorf("IsValidUPC(%q) = %v, want %v", tt.upc, got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,9 @@ type Artist struct {
 
 	// Relationships of the artist
 	Relationships ArtistRelationships `json:"relationships,omitempty"`
+
+	// Views of the artist, present when requested with the views parameter
+	Views ArtistViews `json:"views,omitempty"`
 }
 
 // ArtistAttributes represents the attributes of an artist.
@@ -20,6 +23,9 @@ type ArtistAttributes struct {
 	// The editorial notes.
 	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
 
+	// The motion artwork, when requested with extend.
+	EditorialVideo *EditorialVideo `json:"editorialVideo,omitempty"`
+
 	// The genre names.
 	GenreNames []string `json:"genreNames"`
 
@@ -48,6 +54,34 @@ type ArtistRelationships struct {
 	Station Relationship `json:"station,omitempty"`
 }
 
+// ArtistViews represents the views of an artist.
+type ArtistViews struct {
+	// The artist's top songs.
+	TopSongs View[Song] `json:"top-songs,omitempty"`
+
+	// The artist's featured albums.
+	FeaturedAlbums View[Album] `json:"featured-albums,omitempty"`
+
+	// Artists similar to the artist.
+	SimilarArtists View[Artist] `json:"similar-artists,omitempty"`
+}
+
+// ArtistDetail aggregates an artist with the views shown on an artist page.
+// Views that are missing from the response are left empty.
+type ArtistDetail struct {
+	// The artist.
+	Artist Artist
+
+	// The artist's top songs.
+	TopSongs []Song
+
+	// The artist's featured albums.
+	FeaturedAlbums []Album
+
+	// Artists similar to the artist.
+	SimilarArtists []Artist
+}
+
 // ArtistsResponse represents a response containing artists.
 type ArtistsResponse struct {
 	// The artists data.
@@ -63,7 +97,18 @@ type ArtistsResponse struct {
 	Next string `json:"next,omitempty"`
 }
 
+// Pagination returns the pagination information from the response's meta and next link.
+func (r *ArtistsResponse) Pagination() Pagination {
+	return PaginationFromMeta(r.Meta, r.Next)
+}
+
 // GetArtworkURL returns the URL for the artist artwork with the specified dimensions.
 func (a *Artist) GetArtworkURL(width, height int) string {
-	return a.Attributes.Artwork.URL
-}
\ No newline at end of file
+	return a.Attributes.Artwork.URLForSize(width, height)
+}
+
+// IsAvailable reports whether the artist is available in the storefront it was
+// fetched from. Artists have no play parameters, so only the name is checked.
+func (a *Artist) IsAvailable() bool {
+	return a.Attributes.Name != ""
+}
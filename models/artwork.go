@@ -0,0 +1,115 @@
+package models
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Default colors used when artwork colors are absent or invalid. Colors are
+// hex strings without a leading '#', as returned by the API.
+const (
+	DefaultArtworkBgColor   = "ffffff"
+	DefaultArtworkDarkText  = "000000"
+	DefaultArtworkLightText = "ffffff"
+)
+
+// URLForSize returns the artwork URL with its {w} and {h} placeholders
+// replaced by the given dimensions, and the {c} and {f} placeholders, when
+// present, by the default crop and the JPEG format. A width or height of zero
+// or less uses the artwork's full size.
+func (a Artwork) URLForSize(width, height int) string {
+	if width <= 0 {
+		width = a.Width
+	}
+
+	if height <= 0 {
+		height = a.Height
+	}
+
+	return strings.NewReplacer(
+		"{w}", strconv.Itoa(width),
+		"{h}", strconv.Itoa(height),
+		"{c}", "bb",
+		"{f}", "jpg",
+	).Replace(a.URL)
+}
+
+// IsDarkBackground reports whether the artwork's background color is dark,
+// that is, whether white text contrasts with it better than black text. It
+// returns false if the background color is absent or invalid.
+func (a Artwork) IsDarkBackground() bool {
+	bg, ok := relativeLuminance(a.BgColor)
+	if !ok {
+		return false
+	}
+
+	return contrastRatio(bg, 1) > contrastRatio(bg, 0)
+}
+
+// BestTextColor returns the text color among TextColor1 to TextColor4 with the
+// highest contrast against the background color. If the background color is
+// absent or invalid, DefaultArtworkBgColor is assumed. If no text color is
+// valid, DefaultArtworkLightText or DefaultArtworkDarkText is returned,
+// depending on the background.
+func (a Artwork) BestTextColor() string {
+	bg, ok := relativeLuminance(a.BgColor)
+	if !ok {
+		bg, _ = relativeLuminance(DefaultArtworkBgColor)
+	}
+
+	best := ""
+	bestRatio := 0.0
+	for _, color := range []string{a.TextColor1, a.TextColor2, a.TextColor3, a.TextColor4} {
+		luminance, ok := relativeLuminance(color)
+		if !ok {
+			continue
+		}
+
+		if ratio := contrastRatio(bg, luminance); ratio > bestRatio {
+			best = color
+			bestRatio = ratio
+		}
+	}
+
+	if best != "" {
+		return best
+	}
+
+	if contrastRatio(bg, 1) > contrastRatio(bg, 0) {
+		return DefaultArtworkLightText
+	}
+	return DefaultArtworkDarkText
+}
+
+// relativeLuminance returns the WCAG relative luminance of a hex color such as
+// "f4f4f4" or "#f4f4f4", and false if the color cannot be parsed.
+func relativeLuminance(color string) (float64, bool) {
+	color = strings.TrimPrefix(strings.TrimSpace(color), "#")
+	if len(color) != 6 {
+		return 0, false
+	}
+
+	rgb, err := strconv.ParseUint(color, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	channel := func(shift uint) float64 {
+		c := float64((rgb>>shift)&0xff) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(16) + 0.7152*channel(8) + 0.0722*channel(0), true
+}
+
+// contrastRatio returns the WCAG contrast ratio between two relative luminances.
+func contrastRatio(a, b float64) float64 {
+	if a < b {
+		a, b = b, a
+	}
+	return (a + 0.05) / (b + 0.05)
+}
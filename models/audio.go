@@ -0,0 +1,42 @@
+package models
+
+// Audio traits reported in the audioTraits attribute of songs and albums.
+const (
+	// AudioTraitLossless indicates lossless audio.
+	AudioTraitLossless = "lossless"
+
+	// AudioTraitHiResLossless indicates high-resolution lossless audio.
+	AudioTraitHiResLossless = "hi-res-lossless"
+
+	// AudioTraitAtmos indicates Dolby Atmos audio.
+	AudioTraitAtmos = "atmos"
+
+	// AudioTraitSpatial indicates spatial audio.
+	AudioTraitSpatial = "spatial"
+
+	// AudioTraitLossy indicates lossy stereo audio.
+	AudioTraitLossy = "lossy-stereo"
+)
+
+// Audio variants reported in the audioVariants attribute, returned when
+// "audioVariants" is passed in the extend query parameter.
+const (
+	// AudioVariantDolbyAtmos indicates Dolby Atmos audio.
+	AudioVariantDolbyAtmos = "dolby-atmos"
+
+	// AudioVariantLossless indicates lossless audio.
+	AudioVariantLossless = "lossless"
+
+	// AudioVariantHiResLossless indicates high-resolution lossless audio.
+	AudioVariantHiResLossless = "hi-res-lossless"
+)
+
+// hasAudioQuality reports whether traits or variants contain any of values.
+func hasAudioQuality(traits, variants []string, values ...string) bool {
+	for _, value := range values {
+		if containsString(traits, value) || containsString(variants, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package models
+
+// ChartGroup represents a single chart of one resource type, such as the most
+// played songs.
+type ChartGroup[T any] struct {
+	// The chart identifier, for example "most-played".
+	Chart string `json:"chart"`
+
+	// The localized name of the chart.
+	Name string `json:"name"`
+
+	// The chart order identifier, for example "most-played:songs".
+	OrderID string `json:"orderId"`
+
+	// The chart href.
+	HREF string `json:"href,omitempty"`
+
+	// The href of the next page of the chart.
+	Next string `json:"next,omitempty"`
+
+	// The chart data.
+	Data []T `json:"data"`
+}
+
+// HasNext reports whether the chart has more results.
+func (g *ChartGroup[T]) HasNext() bool {
+	return g.Next != ""
+}
+
+// ChartResponse represents the charts returned for a storefront.
+type ChartResponse struct {
+	// The song charts.
+	Songs []ChartGroup[Song] `json:"songs,omitempty"`
+
+	// The album charts.
+	Albums []ChartGroup[Album] `json:"albums,omitempty"`
+
+	// The playlist charts.
+	Playlists []ChartGroup[Playlist] `json:"playlists,omitempty"`
+}
+
+// ChartOptions represents options for chart requests.
+type ChartOptions struct {
+	// The chart to fetch, for example "most-played". Empty fetches the default charts.
+	Chart string `json:"chart,omitempty"`
+
+	// The genre ID to scope the charts to.
+	Genre string `json:"genre,omitempty"`
+
+	// The number of resources to fetch per chart.
+	Limit int `json:"limit,omitempty"`
+
+	// The offset for the resources to fetch.
+	Offset int `json:"offset,omitempty"`
+}
+
+// IsEmpty reports whether none of the charts contain any resources, as happens
+// for genres with too little activity to chart.
+func (r *ChartResponse) IsEmpty() bool {
+	return chartGroupsEmpty(r.Songs) && chartGroupsEmpty(r.Albums) && chartGroupsEmpty(r.Playlists)
+}
+
+// chartGroupsEmpty reports whether none of the chart groups contain any resources.
+func chartGroupsEmpty[T any](groups []ChartGroup[T]) bool {
+	for _, group := range groups {
+		if len(group.Data) > 0 {
+			return false
+		}
+	}
+	return true
+}
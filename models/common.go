@@ -1,6 +1,14 @@
 // Package models provides data models for the Apple Music API.
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
 // Resource represents a resource in the Apple Music API.
 type Resource struct {
 	// The type of the resource.
@@ -13,6 +21,47 @@ type Resource struct {
 	HREF string `json:"href,omitempty"`
 }
 
+// shareURLPaths maps catalog resource types to their path on music.apple.com.
+var shareURLPaths = map[string]string{
+	"albums":         "album",
+	"apple-curators": "curator",
+	"artists":        "artist",
+	"curators":       "curator",
+	"music-videos":   "music-video",
+	"playlists":      "playlist",
+	"record-labels":  "label",
+	"songs":          "song",
+	"stations":       "station",
+}
+
+// ShareURL returns the public music.apple.com link for the resource in the
+// given storefront, built from its type and ID. It is useful for relationship
+// stubs, which lack an attributes URL; prefer Attributes.URL when it is set.
+// Library resources have no public link, so an empty string is returned for
+// them and for other unknown types.
+func (r Resource) ShareURL(storefront string) string {
+	path, ok := shareURLPaths[r.Type]
+	if !ok || r.ID == "" || storefront == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("https://music.apple.com/%s/%s/%s", url.PathEscape(storefront), path, url.PathEscape(r.ID))
+}
+
+// Storefront returns the storefront a catalog resource was served from, read
+// from its href, such as "us" for "/v1/catalog/us/songs/1". It returns an
+// empty string for library resources and resources without an href.
+func (r Resource) Storefront() string {
+	parts := strings.Split(strings.TrimPrefix(r.HREF, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "catalog" {
+			return parts[i+1]
+		}
+	}
+
+	return ""
+}
+
 // Artwork represents artwork for a resource.
 type Artwork struct {
 	// The width of the artwork in pixels.
@@ -56,6 +105,9 @@ type PlayParameters struct {
 
 	// The catalog ID for the resource.
 	CatalogID string `json:"catalogId,omitempty"`
+
+	// The global ID of a library playlist that has a catalog equivalent.
+	GlobalID string `json:"globalId,omitempty"`
 }
 
 // EditorialNotes represents editorial notes for a resource.
@@ -106,16 +158,50 @@ type Pagination struct {
 	Offset int `json:"offset,omitempty"`
 }
 
-// Chart represents a chart.
-type Chart struct {
-	// The name of the chart.
-	Name string `json:"name"`
+// PaginationFromMeta builds pagination information from a response's meta
+// object and next link. Missing or malformed meta values are left as zero.
+func PaginationFromMeta(meta map[string]interface{}, next string) Pagination {
+	return Pagination{
+		Next:   next,
+		Total:  metaInt(meta, "total"),
+		Limit:  metaInt(meta, "limit"),
+		Offset: metaInt(meta, "offset"),
+	}
+}
 
-	// The chart order.
-	Order string `json:"order"`
+// NextOffset returns the offset of the next page, parsed from the next link,
+// and false if there is no next page.
+func (p Pagination) NextOffset() (int, bool) {
+	if p.Next == "" {
+		return 0, false
+	}
 
-	// The chart data.
-	Data []Resource `json:"data"`
+	next, err := url.Parse(p.Next)
+	if err != nil {
+		return 0, false
+	}
+
+	offset, err := strconv.Atoi(next.Query().Get("offset"))
+	if err != nil || offset <= 0 {
+		return 0, false
+	}
+
+	return offset, true
+}
+
+// metaInt reads an integer value from a meta object.
+func metaInt(meta map[string]interface{}, key string) int {
+	switch v := meta[key].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	default:
+		return 0
+	}
 }
 
 // Relationship represents a relationship between resources.
@@ -128,6 +214,121 @@ type Relationship struct {
 
 	// The relationship next href.
 	Next string `json:"next,omitempty"`
+
+	// The raw JSON of each related resource, including any attributes.
+	Raw []json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a relationship, keeping the raw JSON of each related
+// resource so that included attributes can be decoded with DecodeInto.
+func (r *Relationship) UnmarshalJSON(data []byte) error {
+	type relationship Relationship
+	var aux struct {
+		relationship
+		Data []json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*r = Relationship(aux.relationship)
+	r.Raw = aux.Data
+	if aux.Data == nil {
+		return nil
+	}
+
+	r.Data = make([]Resource, 0, len(aux.Data))
+	for _, raw := range aux.Data {
+		var resource Resource
+		if err := json.Unmarshal(raw, &resource); err != nil {
+			return err
+		}
+		r.Data = append(r.Data, resource)
+	}
+
+	return nil
+}
+
+// DecodeInto decodes the related resources, with their full attributes, into
+// v, which must be a pointer to a slice such as *[]Song.
+func (r Relationship) DecodeInto(v interface{}) error {
+	data, err := json.Marshal(r.Raw)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// View represents a named view of a resource, such as an artist's top songs.
+type View[T any] struct {
+	// The view href.
+	HREF string `json:"href,omitempty"`
+
+	// The href of the next page of the view.
+	Next string `json:"next,omitempty"`
+
+	// The view attributes.
+	Attributes ViewAttributes `json:"attributes,omitempty"`
+
+	// The view data.
+	Data []T `json:"data"`
+}
+
+// ViewAttributes represents the attributes of a view.
+type ViewAttributes struct {
+	// The localized title of the view.
+	Title string `json:"title,omitempty"`
+}
+
+// ViewPage represents a single page of a resource view, fetched on its own
+// rather than as part of the resource. The data is decoded as plain resources;
+// use DecodeInto to decode it with its full attributes, for example into
+// *[]Song for an artist's top songs.
+type ViewPage struct {
+	Relationship
+
+	// The view attributes.
+	Attributes ViewAttributes `json:"attributes,omitempty"`
+
+	// The response meta.
+	Meta map[string]interface{} `json:"meta,omitempty"`
+}
+
+// UnmarshalJSON decodes a view page, keeping the raw JSON of each resource.
+func (p *ViewPage) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Attributes ViewAttributes         `json:"attributes"`
+		Meta       map[string]interface{} `json:"meta"`
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &p.Relationship); err != nil {
+		return err
+	}
+
+	p.Attributes = aux.Attributes
+	p.Meta = aux.Meta
+	return nil
+}
+
+// Pagination returns the pagination information from the page's meta and next
+// link. Use its NextOffset as the offset of the following page.
+func (p *ViewPage) Pagination() Pagination {
+	return PaginationFromMeta(p.Meta, p.Next)
+}
+
+// IDs returns the IDs of the resources in the relationship.
+func (r Relationship) IDs() []string {
+	ids := make([]string, 0, len(r.Data))
+	for _, resource := range r.Data {
+		ids = append(ids, resource.ID)
+	}
+	return ids
 }
 
 // QueryParameters represents query parameters for the Apple Music API.
@@ -144,6 +345,16 @@ type QueryParameters struct {
 	// The fields to exclude from the response.
 	Exclude []string `json:"exclude,omitempty"`
 
+	// The extended attributes to include in the response.
+	Extend []string `json:"extend,omitempty"`
+
+	// The views to include in the response.
+	Views []string `json:"views,omitempty"`
+
+	// The attributes to return for each resource type, sent as fields[type],
+	// for example {"songs": {"name", "artistName"}}.
+	Fields map[string][]string `json:"fields,omitempty"`
+
 	// The language tag for the response.
 	LanguageTag string `json:"l,omitempty"`
 
@@ -166,3 +377,32 @@ type Response struct {
 	Results map[string]interface{} `json:"results,omitempty"`
 }
 
+// ContentRating is the content rating of a song, album, or music video.
+type ContentRating string
+
+const (
+	// ContentRatingExplicit marks content with explicit lyrics.
+	ContentRatingExplicit ContentRating = "explicit"
+
+	// ContentRatingClean marks an edited version of explicit content.
+	ContentRatingClean ContentRating = "clean"
+
+	// ContentRatingNone marks content without a content rating.
+	ContentRatingNone ContentRating = ""
+)
+
+// ParseContentRating parses a contentRating attribute, ignoring case.
+// Unrecognized values parse as ContentRatingNone.
+func ParseContentRating(s string) ContentRating {
+	switch rating := ContentRating(strings.ToLower(s)); rating {
+	case ContentRatingExplicit, ContentRatingClean:
+		return rating
+	default:
+		return ContentRatingNone
+	}
+}
+
+// IsExplicit reports whether the rating is ContentRatingExplicit.
+func (r ContentRating) IsExplicit() bool {
+	return r == ContentRatingExplicit
+}
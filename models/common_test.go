@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPaginationFromMeta(t *testing.T) {
+	data := `{"data":[{"id":"1","type":"songs"}],"meta":{"total":120,"limit":"x"},` +
+		`"next":"/v1/catalog/us/charts?offset=25&types=songs"}`
+
+	var response SongsResponse
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	pagination := response.Pagination()
+	want := Pagination{Next: "/v1/catalog/us/charts?offset=25&types=songs", Total: 120}
+	if pagination != want {
+		t.Errorf("Pagination() = %+v, want %+v", pagination, want)
+	}
+
+	if offset, ok := pagination.NextOffset(); !ok || offset != 25 {
+		t.Errorf("NextOffset() = %d, %v, want 25, true", offset, ok)
+	}
+}
+
+func TestPaginationWithoutMeta(t *testing.T) {
+	pagination := PaginationFromMeta(nil, "")
+
+	if pagination != (Pagination{}) {
+		t.Errorf("PaginationFromMeta(nil) = %+v, want zero", pagination)
+	}
+	if _, ok := pagination.NextOffset(); ok {
+		t.Error("NextOffset() without a next link reported a next page")
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+// EditorialVideo represents the motion artwork of a resource, returned when
+// "editorialVideo" is passed in the extend query parameter.
+type EditorialVideo struct {
+	// The square motion artwork for detail pages.
+	MotionDetailSquare *MotionVideo `json:"motionDetailSquare,omitempty"`
+
+	// The tall motion artwork for detail pages.
+	MotionDetailTall *MotionVideo `json:"motionDetailTall,omitempty"`
+
+	// The 1:1 motion artwork.
+	MotionSquareVideo1x1 *MotionVideo `json:"motionSquareVideo1x1,omitempty"`
+
+	// The 3:4 motion artwork.
+	MotionTallVideo3x4 *MotionVideo `json:"motionTallVideo3x4,omitempty"`
+}
+
+// MotionVideo represents a single motion artwork video.
+type MotionVideo struct {
+	// The still frame to show before the video loads.
+	PreviewFrame Artwork `json:"previewFrame"`
+
+	// The URL of the video, an HLS playlist.
+	Video string `json:"video"`
+}
+
+// BrowseSection represents a section of editorial Browse content, such as
+// "New Music" or "Hits", with the resources featured in it.
+type BrowseSection struct {
+	// The identifier of the section.
+	ID string `json:"id"`
+
+	// The title of the section.
+	Title string `json:"title,omitempty"`
+
+	// The kind of editorial element the section was built from.
+	Kind string `json:"kind,omitempty"`
+
+	// The featured resources, decoded by type with DecodeResource.
+	Contents []interface{} `json:"contents,omitempty"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// editorialVideoJSON is the motion artwork of an album, as returned with
+// extend=editorialVideo.
+const editorialVideoJSON = `{` +
+	`"motionDetailSquare":{"previewFrame":{"width":3840,"height":3840,"url":"https://example.com/square/{w}x{h}bb.jpg"},` +
+	`"video":"https://example.com/square.m3u8"},` +
+	`"motionDetailTall":{"previewFrame":{"width":2048,"height":2732,"url":"https://example.com/tall/{w}x{h}bb.jpg"},` +
+	`"video":"https://example.com/tall.m3u8"},` +
+	`"motionSquareVideo1x1":{"previewFrame":{"width":3840,"height":3840,"url":"https://example.com/1x1/{w}x{h}bb.jpg"},` +
+	`"video":"https://example.com/1x1.m3u8"}}`
+
+func TestDecodeEditorialVideo(t *testing.T) {
+	var album Album
+	data := `{"id":"2","type":"albums","attributes":{"name":"Mock Album","editorialVideo":` + editorialVideoJSON + `}}`
+	if err := json.Unmarshal([]byte(data), &album); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	video := album.Attributes.EditorialVideo
+	if video == nil {
+		t.Fatal("EditorialVideo = nil, want the decoded motion artwork")
+	}
+
+	if video.MotionDetailSquare == nil || video.MotionDetailSquare.Video != "https://example.com/square.m3u8" {
+		t.Errorf("MotionDetailSquare = %+v, want the square video", video.MotionDetailSquare)
+	}
+	if video.MotionDetailTall == nil || video.MotionDetailTall.PreviewFrame.Height != 2732 {
+		t.Errorf("MotionDetailTall = %+v, want a 2732 pixel tall preview frame", video.MotionDetailTall)
+	}
+	if video.MotionSquareVideo1x1 == nil || video.MotionSquareVideo1x1.PreviewFrame.URL != "https://example.com/1x1/{w}x{h}bb.jpg" {
+		t.Errorf("MotionSquareVideo1x1 = %+v, want the 1:1 preview frame", video.MotionSquareVideo1x1)
+	}
+	if video.MotionTallVideo3x4 != nil {
+		t.Errorf("MotionTallVideo3x4 = %+v, want nil when absent", video.MotionTallVideo3x4)
+	}
+
+	var playlist Playlist
+	if err := json.Unmarshal([]byte(`{"id":"pl.1","type":"playlists","attributes":{"editorialVideo":`+editorialVideoJSON+`}}`), &playlist); err != nil {
+		t.Fatalf("Unmarshal() playlist error = %v", err)
+	}
+	if playlist.Attributes.EditorialVideo == nil || playlist.Attributes.EditorialVideo.MotionDetailTall == nil {
+		t.Errorf("playlist EditorialVideo = %+v, want the decoded motion artwork", playlist.Attributes.EditorialVideo)
+	}
+
+	var plain Album
+	if err := json.Unmarshal([]byte(`{"id":"2","type":"albums","attributes":{"name":"Mock Album"}}`), &plain); err != nil {
+		t.Fatalf("Unmarshal() without extend error = %v", err)
+	}
+	if plain.Attributes.EditorialVideo != nil {
+		t.Errorf("EditorialVideo = %+v without extend, want nil", plain.Attributes.EditorialVideo)
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+// Genre represents a genre in the Apple Music API.
+type Genre struct {
+	// Resource information
+	Resource
+
+	// Attributes of the genre
+	Attributes GenreAttributes `json:"attributes,omitempty"`
+}
+
+// GenreAttributes represents the attributes of a genre.
+type GenreAttributes struct {
+	// The localized name of the genre.
+	Name string `json:"name"`
+
+	// The ID of the parent genre.
+	ParentID string `json:"parentId,omitempty"`
+
+	// The localized name of the parent genre.
+	ParentName string `json:"parentName,omitempty"`
+
+	// The localized name to use when displaying the genre in charts.
+	ChartLabel string `json:"chartLabel,omitempty"`
+}
+
+// GenresResponse represents a response containing genres.
+type GenresResponse struct {
+	// The genres data.
+	Data []Genre `json:"data"`
+
+	// The next href.
+	Next string `json:"next,omitempty"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "encoding/json"
+
+// ListResult is a single response of a collection endpoint: its data, together
+// with the pagination information, meta, and raw JSON of the response.
+type ListResult[T any] struct {
+	// The data of the response.
+	Data []T `json:"data"`
+
+	// The href of the next page, or empty if this is the last page.
+	Next string `json:"next,omitempty"`
+
+	// The total number of items in the collection, or zero if unknown.
+	Total int `json:"total,omitempty"`
+
+	// The response meta.
+	Meta map[string]interface{} `json:"meta,omitempty"`
+
+	// The raw JSON of the response, including anything not decoded above.
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes a collection response, keeping its raw JSON and reading
+// Total from the meta.
+func (r *ListResult[T]) UnmarshalJSON(data []byte) error {
+	var response struct {
+		Data []T                    `json:"data"`
+		Next string                 `json:"next"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+
+	*r = ListResult[T]{
+		Data:  response.Data,
+		Next:  response.Next,
+		Total: metaInt(response.Meta, "total"),
+		Meta:  response.Meta,
+		Raw:   append(json.RawMessage(nil), data...),
+	}
+	return nil
+}
+
+// Pagination returns the pagination information of the response.
+func (r *ListResult[T]) Pagination() Pagination {
+	return PaginationFromMeta(r.Meta, r.Next)
+}
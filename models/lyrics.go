@@ -0,0 +1,200 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lyrics represents the lyrics of a song.
+type Lyrics struct {
+	// The identifier of the lyrics, usually the song ID.
+	ID string `json:"id"`
+
+	// The raw TTML document as returned by Apple.
+	Raw string `json:"raw,omitempty"`
+
+	// The lyrics as plain text, one line per line of lyrics and a blank line
+	// between verses.
+	PlainText string `json:"plainText,omitempty"`
+}
+
+// LyricLine represents a single time-synced line of lyrics.
+type LyricLine struct {
+	// The time the line starts, from the start of the song.
+	Start time.Duration `json:"start"`
+
+	// The time the line ends, from the start of the song.
+	End time.Duration `json:"end"`
+
+	// The text of the line.
+	Text string `json:"text"`
+}
+
+// LyricsResponse represents a lyrics response.
+type LyricsResponse struct {
+	Data []struct {
+		Resource
+		Attributes struct {
+			TTML string `json:"ttml"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// NewLyrics creates lyrics from a TTML document, extracting the plain text.
+func NewLyrics(id, ttml string) (*Lyrics, error) {
+	lyrics := &Lyrics{ID: id, Raw: ttml}
+	if ttml == "" {
+		return lyrics, nil
+	}
+
+	paragraphs, err := parseTTML(ttml)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	for i, p := range paragraphs {
+		if i > 0 {
+			if p.verse != paragraphs[i-1].verse {
+				b.WriteString("\n\n")
+			} else {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(p.text)
+	}
+	lyrics.PlainText = b.String()
+
+	return lyrics, nil
+}
+
+// IsSynced reports whether the lyrics are time-synced.
+func (l *Lyrics) IsSynced() bool {
+	lines, err := l.Synced()
+	return err == nil && len(lines) > 0
+}
+
+// Synced parses the time-synced lines of the lyrics from the raw TTML. It
+// returns no lines if the lyrics are not time-synced.
+func (l *Lyrics) Synced() ([]LyricLine, error) {
+	if l.Raw == "" {
+		return nil, nil
+	}
+
+	paragraphs, err := parseTTML(l.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []LyricLine
+	for _, p := range paragraphs {
+		if p.begin == "" {
+			return nil, nil
+		}
+
+		start, err := parseTTMLTime(p.begin)
+		if err != nil {
+			return nil, err
+		}
+
+		line := LyricLine{Start: start, End: start, Text: p.text}
+		if p.end != "" {
+			line.End, err = parseTTMLTime(p.end)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// ttmlParagraph is a line of lyrics in a TTML document.
+type ttmlParagraph struct {
+	begin string
+	end   string
+	text  string
+
+	// Index of the div, or verse, containing the line
+	verse int
+}
+
+// parseTTML extracts the lines of lyrics, the p elements, from a TTML document.
+func parseTTML(ttml string) ([]ttmlParagraph, error) {
+	decoder := xml.NewDecoder(strings.NewReader(ttml))
+
+	var paragraphs []ttmlParagraph
+	var current *ttmlParagraph
+	var text strings.Builder
+	verse := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse TTML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "div":
+				verse++
+			case t.Name.Local == "p":
+				current = &ttmlParagraph{verse: verse}
+				text.Reset()
+				for _, attr := range t.Attr {
+					switch attr.Name.Local {
+					case "begin":
+						current.begin = attr.Value
+					case "end":
+						current.end = attr.Value
+					}
+				}
+			case t.Name.Local == "br" && current != nil:
+				text.WriteString(" ")
+			}
+		case xml.CharData:
+			if current != nil {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if t.Name.Local == "p" && current != nil {
+				current.text = strings.Join(strings.Fields(text.String()), " ")
+				paragraphs = append(paragraphs, *current)
+				current = nil
+			}
+		}
+	}
+
+	return paragraphs, nil
+}
+
+// parseTTMLTime parses a TTML time expression, such as "1:02.345", "62.345",
+// or "62.345s", as a duration.
+func parseTTMLTime(s string) (time.Duration, error) {
+	value := strings.TrimSuffix(s, "s")
+	parts := strings.Split(value, ":")
+	if value == "" || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid TTML time: %q", s)
+	}
+
+	var seconds float64
+	for _, part := range parts {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid TTML time: %q", s)
+		}
+		seconds = seconds*60 + n
+	}
+
+	return time.Duration(math.Round(seconds*1000)) * time.Millisecond, nil
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/marcusziade/musickitkat/mockapi"
+)
+
+// lyricsFixtures serves the time-synced lyrics of song 1 and the lyrics of
+// song 4, which are not time-synced.
+var lyricsFixtures = mockapi.Fixtures{
+	"GET catalog/us/songs/1/lyrics": {Body: `{"data":[{"id":"1","type":"lyrics","attributes":{"ttml":"` +
+		`<tt xmlns=\"http://www.w3.org/ns/ttml\" xmlns:itunes=\"http://music.apple.com/lyric-ttml-internal\" itunes:timing=\"Line\">` +
+		`<body dur=\"3:00.000\"><div begin=\"0:10.000\" end=\"0:20.000\"><p begin=\"0:10.000\" end=\"0:15.000\">First mock line</p>` +
+		`<p begin=\"0:15.000\" end=\"0:20.000\">Second mock line</p></div><div begin=\"0:25.000\" end=\"0:30.000\">` +
+		`<p begin=\"0:25.000\" end=\"0:30.000\">Third mock line</p></div></body></tt>"}}]}`},
+	"GET catalog/us/songs/4/lyrics": {Body: `{"data":[{"id":"4","type":"lyrics","attributes":{"ttml":"` +
+		`<tt xmlns=\"http://www.w3.org/ns/ttml\" xmlns:itunes=\"http://music.apple.com/lyric-ttml-internal\" itunes:timing=\"None\">` +
+		`<body><div><p>First plain line</p><p>Second plain line</p></div><div><p>Third plain line</p></div></body></tt>"}}]}`},
+}
+
+// fixtureLyrics decodes the lyrics served by lyricsFixtures for route.
+func fixtureLyrics(t *testing.T, route string) *Lyrics {
+	t.Helper()
+
+	fixture, ok := lyricsFixtures[route]
+	if !ok {
+		t.Fatalf("no fixture for %s", route)
+	}
+
+	var response LyricsResponse
+	if err := json.Unmarshal([]byte(fixture.Body), &response); err != nil {
+		t.Fatalf("failed to decode %s: %v", route, err)
+	}
+	if len(response.Data) != 1 {
+		t.Fatalf("%s has %d lyrics, want 1", route, len(response.Data))
+	}
+
+	lyrics, err := NewLyrics(response.Data[0].ID, response.Data[0].Attributes.TTML)
+	if err != nil {
+		t.Fatalf("NewLyrics() error = %v", err)
+	}
+	return lyrics
+}
+
+func TestSyncedLyrics(t *testing.T) {
+	lyrics := fixtureLyrics(t, "GET catalog/us/songs/1/lyrics")
+
+	if want := "First mock line\nSecond mock line\n\nThird mock line"; lyrics.PlainText != want {
+		t.Errorf("PlainText = %q, want %q", lyrics.PlainText, want)
+	}
+
+	if !lyrics.IsSynced() {
+		t.Error("IsSynced() = false, want true")
+	}
+
+	lines, err := lyrics.Synced()
+	if err != nil {
+		t.Fatalf("Synced() error = %v", err)
+	}
+
+	want := []LyricLine{
+		{Start: 10 * time.Second, End: 15 * time.Second, Text: "First mock line"},
+		{Start: 15 * time.Second, End: 20 * time.Second, Text: "Second mock line"},
+		{Start: 25 * time.Second, End: 30 * time.Second, Text: "Third mock line"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Synced() returned %d lines, want %d", len(lines), len(want))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestUnsyncedLyrics(t *testing.T) {
+	lyrics := fixtureLyrics(t, "GET catalog/us/songs/4/lyrics")
+
+	if lyrics.ID != "4" {
+		t.Errorf("ID = %q, want %q", lyrics.ID, "4")
+	}
+
+	if want := "First plain line\nSecond plain line\n\nThird plain line"; lyrics.PlainText != want {
+		t.Errorf("PlainText = %q, want %q", lyrics.PlainText, want)
+	}
+
+	if lyrics.IsSynced() {
+		t.Error("IsSynced() = true, want false")
+	}
+
+	lines, err := lyrics.Synced()
+	if err != nil || lines != nil {
+		t.Errorf("Synced() = %v, %v, want no lines and no error", lines, err)
+	}
+}
+
+func TestNewLyricsInvalidTTML(t *testing.T) {
+	if _, err := NewLyrics("1", "<tt><body><p>unterminated"); err == nil {
+		t.Error("NewLyrics() with malformed TTML returned no error")
+	}
+
+	lyrics, err := NewLyrics("1", "")
+	if err != nil || lyrics.PlainText != "" || lyrics.IsSynced() {
+		t.Errorf("NewLyrics() without TTML = %+v, %v, want empty lyrics", lyrics, err)
+	}
+}
+
+func TestParseTTMLTime(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"1:02.345", time.Minute + 2345*time.Millisecond},
+		{"62.345", 62345 * time.Millisecond},
+		{"62.345s", 62345 * time.Millisecond},
+		{"1:00:00.5", time.Hour + 500*time.Millisecond},
+		{"0", 0},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTTMLTime(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("parseTTMLTime(%q) = %v, %v, want %v", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "s", "abc", "-1", "1:2:3:4", "1::2"} {
+		if _, err := parseTTMLTime(input); err == nil {
+			t.Errorf("parseTTMLTime(%q) returned no error", input)
+		}
+	}
+}
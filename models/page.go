@@ -0,0 +1,45 @@
+package models
+
+// Page is a page of a collection addressed by page number rather than offset.
+type Page[T any] struct {
+	// The page data.
+	Data []T `json:"data"`
+
+	// The zero-based page number.
+	PageNumber int `json:"pageNumber"`
+
+	// The maximum number of items per page.
+	PageSize int `json:"pageSize"`
+
+	// The total number of items in the collection, or zero if unknown.
+	Total int `json:"total,omitempty"`
+
+	// Whether there is a page after this one.
+	HasNext bool `json:"hasNext"`
+
+	// Whether there is a page before this one.
+	HasPrev bool `json:"hasPrev"`
+}
+
+// NewPage builds a page from its data and the pagination information of the
+// response it came from.
+func NewPage[T any](data []T, pageNumber, pageSize int, pagination Pagination) *Page[T] {
+	hasNext := pagination.Next != ""
+	if !hasNext && pagination.Total > 0 {
+		hasNext = (pageNumber+1)*pageSize < pagination.Total
+	}
+
+	return &Page[T]{
+		Data:       data,
+		PageNumber: pageNumber,
+		PageSize:   pageSize,
+		Total:      pagination.Total,
+		HasNext:    hasNext,
+		HasPrev:    pageNumber > 0,
+	}
+}
+
+// PageOffset returns the offset of the first item of a zero-based page.
+func PageOffset(pageNumber, pageSize int) int {
+	return pageNumber * pageSize
+}
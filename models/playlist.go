@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // Playlist represents a playlist in the Apple Music API.
 type Playlist struct {
@@ -25,6 +29,9 @@ type PlaylistAttributes struct {
 	// The description.
 	Description EditorialNotes `json:"description,omitempty"`
 
+	// The motion artwork, when requested with extend.
+	EditorialVideo *EditorialVideo `json:"editorialVideo,omitempty"`
+
 	// Whether the playlist is a featured playlist.
 	IsFeatured bool `json:"isFeatured,omitempty"`
 
@@ -52,13 +59,22 @@ type PlaylistRelationships struct {
 	// The curator relationship.
 	Curator Relationship `json:"curator,omitempty"`
 
-	// The tracks relationship.
+	// The tracks relationship. Use Playlist.Tracks to decode included tracks.
 	Tracks Relationship `json:"tracks,omitempty"`
 
 	// The featured artists relationship.
 	FeaturedArtists Relationship `json:"featured-artists,omitempty"`
 }
 
+// PlaylistTrack identifies a track to add to a playlist.
+type PlaylistTrack struct {
+	// The catalog or library ID of the track.
+	ID string `json:"id"`
+
+	// The type of the track, for example "songs" or "music-videos".
+	Type string `json:"type"`
+}
+
 // PlaylistsResponse represents a response containing playlists.
 type PlaylistsResponse struct {
 	// The playlists data.
@@ -74,12 +90,168 @@ type PlaylistsResponse struct {
 	Next string `json:"next,omitempty"`
 }
 
+// Pagination returns the pagination information from the response's meta and next link.
+func (r *PlaylistsResponse) Pagination() Pagination {
+	return PaginationFromMeta(r.Meta, r.Next)
+}
+
+// Tracks decodes the tracks of the playlist, which carry their attributes when
+// the playlist is fetched with include=tracks. Songs and music videos, from the
+// catalog or the library, are decoded as *Song and *MusicVideo; other types
+// are decoded as by DecodeResource.
+func (p *Playlist) Tracks() ([]interface{}, error) {
+	tracks := make([]interface{}, 0, len(p.Relationships.Tracks.Raw))
+	for _, raw := range p.Relationships.Tracks.Raw {
+		track, err := decodeTrack(raw)
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// decodeTrack decodes a playlist track, decoding library songs and music
+// videos like their catalog counterparts.
+func decodeTrack(data []byte) (interface{}, error) {
+	var resource Resource
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+
+	var track interface{}
+	switch ResourceType(resource.Type) {
+	case ResourceTypeLibrarySongs:
+		track = &Song{}
+	case ResourceTypeLibraryMusicVideos:
+		track = &MusicVideo{}
+	default:
+		return DecodeResource(data)
+	}
+
+	if err := json.Unmarshal(data, track); err != nil {
+		return nil, err
+	}
+
+	return track, nil
+}
+
 // GetArtworkURL returns the URL for the playlist artwork with the specified dimensions.
 func (p *Playlist) GetArtworkURL(width, height int) string {
-	return p.Attributes.Artwork.URL
+	return p.Attributes.Artwork.URLForSize(width, height)
 }
 
-// FormatLastModifiedDate formats the last modified date as a time.Time.
+// IsAvailable reports whether the playlist is playable in the storefront it
+// was fetched from. Playlists unavailable in a storefront may be returned as
+// placeholders without a name or play parameters.
+func (p *Playlist) IsAvailable() bool {
+	return p.Attributes.Name != "" && p.Attributes.PlayParams.ID != ""
+}
+
+// FormatLastModifiedDate formats the last modified date as a time.Time. It
+// accepts the formats Apple uses for it: RFC 3339 with or without fractional
+// seconds, a date and time without a time zone, taken as UTC, and a date only.
 func (p *Playlist) FormatLastModifiedDate() (time.Time, error) {
-	return time.Parse(time.RFC3339, p.Attributes.LastModifiedDate)
-}
\ No newline at end of file
+	return parseTimestamp(p.Attributes.LastModifiedDate)
+}
+
+// timestampLayouts lists the layouts parseTimestamp tries, in order.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// parseTimestamp parses a timestamp in any of timestampLayouts.
+func parseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q: expected RFC 3339 or YYYY-MM-DD", value)
+}
+
+// RootPlaylistFolderID is the identifier of the root folder of the user's playlist tree.
+const RootPlaylistFolderID = "p.playlistsroot"
+
+// PlaylistFolder represents a folder of playlists in the user's library.
+type PlaylistFolder struct {
+	// Resource information
+	Resource
+
+	// Attributes of the folder
+	Attributes PlaylistFolderAttributes `json:"attributes,omitempty"`
+}
+
+// PlaylistFolderAttributes represents the attributes of a playlist folder.
+type PlaylistFolderAttributes struct {
+	// The name of the folder.
+	Name string `json:"name"`
+
+	// The date the folder was added to the library.
+	DateAdded string `json:"dateAdded,omitempty"`
+}
+
+// PlaylistFoldersResponse represents a response containing playlist folders.
+type PlaylistFoldersResponse struct {
+	// The folders data.
+	Data []PlaylistFolder `json:"data"`
+
+	// The response errors.
+	Errors []interface{} `json:"errors,omitempty"`
+
+	// The response meta.
+	Meta map[string]interface{} `json:"meta,omitempty"`
+
+	// The next URL.
+	Next string `json:"next,omitempty"`
+}
+
+// PlaylistFolderContents represents the playlists and subfolders contained in a folder.
+type PlaylistFolderContents struct {
+	// The subfolders and playlists, interleaved in the order returned by the API.
+	Entries []PlaylistFolderEntry
+
+	// The next URL.
+	Next string
+}
+
+// PlaylistFolderEntry represents an item of a folder, either a subfolder or a
+// playlist. Exactly one of Folder and Playlist is set.
+type PlaylistFolderEntry struct {
+	// The subfolder, or nil if the entry is a playlist.
+	Folder *PlaylistFolder
+
+	// The playlist, or nil if the entry is a subfolder.
+	Playlist *Playlist
+}
+
+// IsFolder reports whether the entry is a subfolder.
+func (e PlaylistFolderEntry) IsFolder() bool {
+	return e.Folder != nil
+}
+
+// Folders returns the subfolders of the folder, in order.
+func (c *PlaylistFolderContents) Folders() []PlaylistFolder {
+	var folders []PlaylistFolder
+	for _, entry := range c.Entries {
+		if entry.Folder != nil {
+			folders = append(folders, *entry.Folder)
+		}
+	}
+	return folders
+}
+
+// Playlists returns the playlists in the folder, in order.
+func (c *PlaylistFolderContents) Playlists() []Playlist {
+	var playlists []Playlist
+	for _, entry := range c.Entries {
+		if entry.Playlist != nil {
+			playlists = append(playlists, *entry.Playlist)
+		}
+	}
+	return playlists
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-03-01T12:30:45Z", time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC)},
+		{"2024-03-01T12:30:45.123Z", time.Date(2024, 3, 1, 12, 30, 45, 123000000, time.UTC)},
+		{"2024-03-01T12:30:45+02:00", time.Date(2024, 3, 1, 10, 30, 45, 0, time.UTC)},
+		{"2024-03-01T12:30:45", time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC)},
+		{"2024-03-01T12:30:45.5", time.Date(2024, 3, 1, 12, 30, 45, 500000000, time.UTC)},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTimestamp(tt.value)
+		if err != nil {
+			t.Errorf("parseTimestamp(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseTimestamp(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimestampInvalid(t *testing.T) {
+	for _, value := range []string{"", "01/03/2024", "2024-03-01 12:30:45", "2024-13-01"} {
+		if _, err := parseTimestamp(value); err == nil {
+			t.Errorf("parseTimestamp(%q) error = nil, want an error", value)
+		}
+	}
+}
+
+func TestFormatLastModifiedDate(t *testing.T) {
+	playlist := Playlist{Attributes: PlaylistAttributes{LastModifiedDate: "2024-03-01T12:30:45Z"}}
+
+	got, err := playlist.FormatLastModifiedDate()
+	if err != nil {
+		t.Fatalf("FormatLastModifiedDate() error = %v", err)
+	}
+	if want := time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("FormatLastModifiedDate() = %v, want %v", got, want)
+	}
+}
+
+func TestPlaylistTracks(t *testing.T) {
+	data := `{"id":"pl.1","type":"playlists","relationships":{"tracks":{"href":"/v1/catalog/us/playlists/pl.1/tracks","data":[` +
+		`{"id":"1","type":"songs","attributes":{"name":"Mock Song","artistName":"Mock Artist"}},` +
+		`{"id":"5","type":"music-videos","attributes":{"name":"Mock Video","artistName":"Mock Artist"}},` +
+		`{"id":"i.1","type":"library-songs","attributes":{"name":"Library Song"}},` +
+		`{"id":"i.2","type":"library-music-videos","attributes":{"name":"Library Video"}}]}}}`
+
+	var playlist Playlist
+	if err := json.Unmarshal([]byte(data), &playlist); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if ids := playlist.Relationships.Tracks.IDs(); len(ids) != 4 || ids[1] != "5" {
+		t.Errorf("Relationships.Tracks.IDs() = %v, want the four track IDs", ids)
+	}
+
+	tracks, err := playlist.Tracks()
+	if err != nil {
+		t.Fatalf("Tracks() error = %v", err)
+	}
+	if len(tracks) != 4 {
+		t.Fatalf("Tracks() returned %d tracks, want 4", len(tracks))
+	}
+
+	if song, ok := tracks[0].(*Song); !ok || song.Attributes.Name != "Mock Song" {
+		t.Errorf("track 1 = %#v, want *Song Mock Song", tracks[0])
+	}
+	if video, ok := tracks[1].(*MusicVideo); !ok || video.Attributes.Name != "Mock Video" {
+		t.Errorf("track 2 = %#v, want *MusicVideo Mock Video", tracks[1])
+	}
+	if song, ok := tracks[2].(*Song); !ok || song.Attributes.Name != "Library Song" {
+		t.Errorf("track 3 = %#v, want *Song Library Song", tracks[2])
+	}
+	if video, ok := tracks[3].(*MusicVideo); !ok || video.Attributes.Name != "Library Video" {
+		t.Errorf("track 4 = %#v, want *MusicVideo Library Video", tracks[3])
+	}
+}
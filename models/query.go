@@ -0,0 +1,82 @@
+package models
+
+import "fmt"
+
+// ValidIncludes lists the relationships that can be passed in the include query
+// parameter, by resource type.
+var ValidIncludes = map[string][]string{
+	"albums":                  {"artists", "genres", "library", "record-labels", "tracks"},
+	"artists":                 {"albums", "genres", "music-videos", "playlists", "station"},
+	"library-albums":          {"artists", "catalog", "tracks"},
+	"library-artists":         {"albums", "catalog"},
+	"library-playlists":       {"catalog", "tracks"},
+	"library-songs":           {"albums", "artists", "catalog"},
+	"music-videos":            {"albums", "artists", "genres", "library", "songs"},
+	"personal-recommendation": {"contents"},
+	"playlists":               {"curator", "library", "tracks"},
+	"songs":                   {"albums", "artists", "composers", "genres", "library", "music-videos", "station"},
+	"stations":                {"radio-show"},
+}
+
+// ValidExtends lists the attributes that can be passed in the extend query
+// parameter, by resource type.
+var ValidExtends = map[string][]string{
+	"albums":    {"artistUrl", "audioVariants", "editorialArtwork", "editorialVideo"},
+	"artists":   {"artistBio", "bornOrFormed", "editorialArtwork", "editorialVideo", "hero", "isGroup", "origin", "plainEditorialNotes"},
+	"playlists": {"editorialArtwork", "editorialVideo", "trackTypes"},
+	"songs":     {"artistUrl", "audioVariants", "editorialArtwork", "editorialVideo", "hasTimeSyncedLyrics"},
+}
+
+// ValidViews lists the views that can be passed in the views query parameter,
+// by resource type.
+var ValidViews = map[string][]string{
+	"albums": {"appears-on", "other-versions", "related-albums", "related-videos"},
+	"artists": {
+		"appears-on-albums", "compilation-albums", "featured-albums", "featured-music-videos",
+		"featured-playlists", "full-albums", "latest-release", "live-albums", "similar-artists",
+		"singles", "top-music-videos", "top-songs",
+	},
+	"playlists": {"featured-artists", "more-by-curator"},
+}
+
+// ValidateQueryParameters checks the include, extend, and views values in
+// params against ValidIncludes, ValidExtends, and ValidViews for the given
+// resource type. Parameters for resource types that are not listed are not
+// checked.
+func ValidateQueryParameters(resourceType string, params QueryParameters) error {
+	if err := validateValues(resourceType, "include", params.Include, ValidIncludes); err != nil {
+		return err
+	}
+
+	if err := validateValues(resourceType, "extend", params.Extend, ValidExtends); err != nil {
+		return err
+	}
+
+	return validateValues(resourceType, "views", params.Views, ValidViews)
+}
+
+// validateValues checks that each value is listed for the resource type in valid.
+func validateValues(resourceType, name string, values []string, valid map[string][]string) error {
+	allowed, ok := valid[resourceType]
+	if !ok {
+		return nil
+	}
+
+	for _, value := range values {
+		if !containsString(allowed, value) {
+			return fmt.Errorf("invalid %s value %q for %s", name, value, resourceType)
+		}
+	}
+
+	return nil
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
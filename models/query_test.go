@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestValidateQueryParametersDocumentedExtends(t *testing.T) {
+	// Extend values that the model documentation tells callers to pass
+	documented := map[string][]string{
+		"albums":    {"artistUrl", "audioVariants", "editorialVideo"},
+		"artists":   {"editorialVideo"},
+		"playlists": {"editorialVideo"},
+		"songs":     {"artistUrl", "audioVariants", "hasTimeSyncedLyrics"},
+	}
+
+	for resourceType, extend := range documented {
+		if err := ValidateQueryParameters(resourceType, QueryParameters{Extend: extend}); err != nil {
+			t.Errorf("ValidateQueryParameters(%s) error = %v", resourceType, err)
+		}
+	}
+}
+
+func TestValidateQueryParametersRejectsUnknownValues(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		params       QueryParameters
+	}{
+		{"songs", QueryParameters{Include: []string{"curator"}}},
+		{"songs", QueryParameters{Extend: []string{"trackTypes"}}},
+		{"albums", QueryParameters{Views: []string{"top-songs"}}},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateQueryParameters(tt.resourceType, tt.params); err == nil {
+			t.Errorf("ValidateQueryParameters(%s, %+v) returned no error", tt.resourceType, tt.params)
+		}
+	}
+
+	if err := ValidateQueryParameters("stations", QueryParameters{Extend: []string{"anything"}}); err != nil {
+		t.Errorf("ValidateQueryParameters() for a type without listed extends error = %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+// RadioShow represents an Apple Music Radio show.
+type RadioShow struct {
+	// Resource information
+	Resource
+
+	// Attributes of the radio show
+	Attributes RadioShowAttributes `json:"attributes,omitempty"`
+}
+
+// RadioShowAttributes represents the attributes of a radio show.
+type RadioShowAttributes struct {
+	// The radio show artwork.
+	Artwork Artwork `json:"artwork,omitempty"`
+
+	// The editorial notes.
+	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
+
+	// The name of the radio show.
+	Name string `json:"name"`
+
+	// The name of the radio show's host.
+	HostName string `json:"hostName,omitempty"`
+
+	// The URL.
+	URL string `json:"url,omitempty"`
+}
+
+// RadioShowsResponse represents a response containing radio shows.
+type RadioShowsResponse struct {
+	// The radio shows data.
+	Data []RadioShow `json:"data"`
+
+	// The response errors.
+	Errors []interface{} `json:"errors,omitempty"`
+
+	// The response meta.
+	Meta map[string]interface{} `json:"meta,omitempty"`
+
+	// The next URL.
+	Next string `json:"next,omitempty"`
+}
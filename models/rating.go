@@ -0,0 +1,32 @@
+package models
+
+// Rating values: a user either loves or dislikes a resource.
+const (
+	// RatingLove is the rating value of a loved resource.
+	RatingLove = 1
+
+	// RatingDislike is the rating value of a disliked resource.
+	RatingDislike = -1
+)
+
+// Rating represents the user's rating of a resource in the Apple Music API.
+// The ID of a rating is the ID of the rated resource.
+type Rating struct {
+	// Resource information
+	Resource
+
+	// Attributes of the rating
+	Attributes RatingAttributes `json:"attributes,omitempty"`
+}
+
+// RatingAttributes represents the attributes of a rating.
+type RatingAttributes struct {
+	// The rating value, RatingLove or RatingDislike.
+	Value int `json:"value"`
+}
+
+// RatingsResponse represents a response containing ratings.
+type RatingsResponse struct {
+	// The ratings data.
+	Data []Rating `json:"data"`
+}
@@ -0,0 +1,75 @@
+package models
+
+// Recommendation represents a personal recommendation, a row of content such
+// as "Made for You" or a group of further recommendations.
+type Recommendation struct {
+	Resource
+	Attributes    RecommendationAttributes    `json:"attributes"`
+	Relationships RecommendationRelationships `json:"relationships"`
+}
+
+// RecommendationAttributes represents the attributes of a recommendation.
+type RecommendationAttributes struct {
+	// Whether the recommendation is a group of recommendations rather than a
+	// row of content.
+	IsGroupRecommendation bool `json:"isGroupRecommendation"`
+
+	// The localized title of the recommendation.
+	Title RecommendationText `json:"title"`
+
+	// The localized reason for the recommendation, such as "Because you
+	// listened to...".
+	Reason RecommendationText `json:"reason,omitempty"`
+
+	// The types of the resources in the recommendation.
+	ResourceTypes []string `json:"resourceTypes,omitempty"`
+
+	// The kind of the recommendation, for example "music-recommendations".
+	Kind string `json:"kind,omitempty"`
+
+	// The date the recommendation is next updated.
+	NextUpdateDate string `json:"nextUpdateDate,omitempty"`
+}
+
+// RecommendationText represents localized text of a recommendation.
+type RecommendationText struct {
+	// The text for display.
+	StringForDisplay string `json:"stringForDisplay"`
+}
+
+// RecommendationRelationships represents the relationships of a recommendation.
+type RecommendationRelationships struct {
+	// The contents of the recommendation, such as albums and playlists.
+	Contents Relationship `json:"contents"`
+
+	// The recommendations of a group recommendation.
+	Recommendations Relationship `json:"recommendations,omitempty"`
+}
+
+// Title returns the display title of the recommendation.
+func (r *Recommendation) Title() string {
+	return r.Attributes.Title.StringForDisplay
+}
+
+// Contents decodes the contents of the recommendation into typed values, as
+// by DecodeResource, such as *Album and *Playlist. Without attributes, which
+// are only returned when the contents are included, the values only carry
+// their type and ID.
+func (r *Recommendation) Contents() ([]interface{}, error) {
+	contents := make([]interface{}, 0, len(r.Relationships.Contents.Raw))
+	for _, raw := range r.Relationships.Contents.Raw {
+		content, err := DecodeResource(raw)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+
+	return contents, nil
+}
+
+// ContentsNext returns the href of the next page of the contents, or an empty
+// string if there are no more contents.
+func (r *Recommendation) ContentsNext() string {
+	return r.Relationships.Contents.Next
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceType is the type of an Apple Music API resource, as it appears in
+// the type field of resources and in request bodies.
+type ResourceType string
+
+const (
+	// ResourceTypeSongs is the type of catalog songs.
+	ResourceTypeSongs ResourceType = "songs"
+
+	// ResourceTypeAlbums is the type of catalog albums.
+	ResourceTypeAlbums ResourceType = "albums"
+
+	// ResourceTypeArtists is the type of catalog artists.
+	ResourceTypeArtists ResourceType = "artists"
+
+	// ResourceTypePlaylists is the type of catalog playlists.
+	ResourceTypePlaylists ResourceType = "playlists"
+
+	// ResourceTypeMusicVideos is the type of catalog music videos.
+	ResourceTypeMusicVideos ResourceType = "music-videos"
+
+	// ResourceTypeStations is the type of catalog stations.
+	ResourceTypeStations ResourceType = "stations"
+
+	// ResourceTypeCurators is the type of catalog curators.
+	ResourceTypeCurators ResourceType = "curators"
+
+	// ResourceTypeAppleCurators is the type of Apple curators.
+	ResourceTypeAppleCurators ResourceType = "apple-curators"
+
+	// ResourceTypeRecordLabels is the type of record labels.
+	ResourceTypeRecordLabels ResourceType = "record-labels"
+
+	// ResourceTypeGenres is the type of genres.
+	ResourceTypeGenres ResourceType = "genres"
+
+	// ResourceTypeLibrarySongs is the type of library songs.
+	ResourceTypeLibrarySongs ResourceType = "library-songs"
+
+	// ResourceTypeLibraryAlbums is the type of library albums.
+	ResourceTypeLibraryAlbums ResourceType = "library-albums"
+
+	// ResourceTypeLibraryArtists is the type of library artists.
+	ResourceTypeLibraryArtists ResourceType = "library-artists"
+
+	// ResourceTypeLibraryPlaylists is the type of library playlists.
+	ResourceTypeLibraryPlaylists ResourceType = "library-playlists"
+
+	// ResourceTypeLibraryMusicVideos is the type of library music videos.
+	ResourceTypeLibraryMusicVideos ResourceType = "library-music-videos"
+
+	// ResourceTypeLibraryPlaylistFolders is the type of library playlist folders.
+	ResourceTypeLibraryPlaylistFolders ResourceType = "library-playlist-folders"
+)
+
+// libraryPrefix is the prefix that distinguishes library types from catalog types.
+const libraryPrefix = "library-"
+
+// resourceTypes lists every known resource type.
+var resourceTypes = []ResourceType{
+	ResourceTypeSongs,
+	ResourceTypeAlbums,
+	ResourceTypeArtists,
+	ResourceTypePlaylists,
+	ResourceTypeMusicVideos,
+	ResourceTypeStations,
+	ResourceTypeCurators,
+	ResourceTypeAppleCurators,
+	ResourceTypeRecordLabels,
+	ResourceTypeGenres,
+	ResourceTypeLibrarySongs,
+	ResourceTypeLibraryAlbums,
+	ResourceTypeLibraryArtists,
+	ResourceTypeLibraryPlaylists,
+	ResourceTypeLibraryMusicVideos,
+	ResourceTypeLibraryPlaylistFolders,
+}
+
+// String returns the resource type as it appears in the API.
+func (t ResourceType) String() string {
+	return string(t)
+}
+
+// ParseResourceType parses a resource type such as "songs" or "library-songs".
+// It returns an error for unknown types.
+func ParseResourceType(s string) (ResourceType, error) {
+	for _, t := range resourceTypes {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown resource type: %s", s)
+}
+
+// IsLibrary reports whether the type is a library type.
+func (t ResourceType) IsLibrary() bool {
+	return strings.HasPrefix(string(t), libraryPrefix)
+}
+
+// LibraryType returns the library variant of a catalog type, for example
+// "library-songs" for "songs". It returns false if there is none.
+func (t ResourceType) LibraryType() (ResourceType, bool) {
+	if t.IsLibrary() {
+		return t, true
+	}
+
+	libraryType, err := ParseResourceType(libraryPrefix + string(t))
+	return libraryType, err == nil
+}
+
+// CatalogType returns the catalog variant of a library type, for example
+// "songs" for "library-songs". It returns false if there is none.
+func (t ResourceType) CatalogType() (ResourceType, bool) {
+	if !t.IsLibrary() {
+		return t, true
+	}
+
+	catalogType, err := ParseResourceType(strings.TrimPrefix(string(t), libraryPrefix))
+	return catalogType, err == nil
+}
@@ -1,12 +1,86 @@
 package models
 
+import "encoding/json"
+
 // SearchResults represents search results from the Apple Music API.
 type SearchResults struct {
-	// The response meta.
+	// The response meta, kept in full, such as the result order and the
+	// metrics Apple returns for search analytics. See Attribution for the
+	// known fields.
 	Meta map[string]interface{} `json:"meta,omitempty"`
 
 	// The response results.
 	Results SearchResultsData `json:"results"`
+
+	// The result sections in the order Apple intends them to be shown, such as
+	// "top", "songs", "albums", from meta.results.order.
+	SectionOrder []string `json:"-"`
+}
+
+// UnmarshalJSON decodes search results, reading SectionOrder from the meta.
+func (r *SearchResults) UnmarshalJSON(data []byte) error {
+	type searchResults SearchResults
+	if err := json.Unmarshal(data, (*searchResults)(r)); err != nil {
+		return err
+	}
+
+	results, _ := r.Meta["results"].(map[string]interface{})
+	r.SectionOrder = stringSlice(results["order"])
+
+	return nil
+}
+
+// Order returns the result sections in the order Apple intends them to be
+// shown, or nil if the response did not include an order.
+func (r *SearchResults) Order() []string {
+	return r.SectionOrder
+}
+
+// SearchAttribution holds the known fields of the meta of search results, which
+// some integrations must report for search analytics.
+type SearchAttribution struct {
+	// The result sections in the order Apple intends them to be shown, from
+	// meta.results.order.
+	Order []string `json:"order,omitempty"`
+
+	// The result sections in the order they were ranked before any reordering,
+	// from meta.results.rawOrder.
+	RawOrder []string `json:"rawOrder,omitempty"`
+
+	// The identifier of the data set that produced the results, from
+	// meta.metrics.dataSetId.
+	DataSetID string `json:"dataSetId,omitempty"`
+
+	// The full meta, including fields not listed above.
+	Raw map[string]interface{} `json:"raw,omitempty"`
+}
+
+// Attribution returns the known fields of the meta of the results. Fields
+// missing from the response are left empty.
+func (r *SearchResults) Attribution() SearchAttribution {
+	results, _ := r.Meta["results"].(map[string]interface{})
+	metrics, _ := r.Meta["metrics"].(map[string]interface{})
+	dataSetID, _ := metrics["dataSetId"].(string)
+
+	return SearchAttribution{
+		Order:     r.SectionOrder,
+		RawOrder:  stringSlice(results["rawOrder"]),
+		DataSetID: dataSetID,
+		Raw:       r.Meta,
+	}
+}
+
+// stringSlice returns the strings of a decoded JSON array.
+func stringSlice(v interface{}) []string {
+	items, _ := v.([]interface{})
+
+	var strs []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
 }
 
 // SearchResultsData represents the data in search results.
@@ -31,18 +105,68 @@ type SearchResultsData struct {
 
 	// The top results.
 	TopResults TopResultsResponse `json:"top,omitempty"`
-	
+
 	// The curator results.
 	Curators CuratorsResponse `json:"curators,omitempty"`
-	
+
 	// The radio station results.
 	RadioStations RadioStationsResponse `json:"radio-stations,omitempty"`
-	
+
 	// The apple curators results.
 	AppleCurators AppleCuratorsResponse `json:"apple-curators,omitempty"`
-	
+
 	// The record label results.
 	RecordLabels RecordLabelsResponse `json:"record-labels,omitempty"`
+
+	// The library song results.
+	LibrarySongs SongsResponse `json:"library-songs,omitempty"`
+
+	// The library album results.
+	LibraryAlbums AlbumsResponse `json:"library-albums,omitempty"`
+
+	// The library artist results.
+	LibraryArtists ArtistsResponse `json:"library-artists,omitempty"`
+
+	// The library playlist results.
+	LibraryPlaylists PlaylistsResponse `json:"library-playlists,omitempty"`
+
+	// The library music video results.
+	LibraryMusicVideos MusicVideosResponse `json:"library-music-videos,omitempty"`
+}
+
+// CombinedSearchResults represents the results of searching the catalog and
+// the user's library together.
+type CombinedSearchResults struct {
+	// The catalog results.
+	Catalog *SearchResults `json:"catalog,omitempty"`
+
+	// The library results, or nil if the library was not searched or the
+	// library search failed.
+	Library *SearchResults `json:"library,omitempty"`
+
+	// The error of the library search, if it failed. The catalog results are
+	// returned regardless.
+	LibraryErr error `json:"-"`
+
+	// The catalog and library results merged, with catalog items the user has
+	// in their library appearing once and marked as in the library.
+	Items []SearchItem `json:"items,omitempty"`
+}
+
+// SearchItem represents a single merged search result.
+type SearchItem struct {
+	// The catalog resource, or the library resource for items that are only in
+	// the user's library.
+	Resource
+
+	// The name of the item.
+	Name string `json:"name"`
+
+	// Whether the item is in the user's library.
+	InLibrary bool `json:"inLibrary"`
+
+	// The ID of the item in the user's library, if it is in the library.
+	LibraryID string `json:"libraryId,omitempty"`
 }
 
 // SearchOptions represents options for search requests.
@@ -58,20 +182,37 @@ type SearchOptions struct {
 
 	// The language tag.
 	LanguageTag string `json:"l,omitempty"`
-	
-	// The types to include.
+
+	// The types to search, used when no types are passed to the search
+	// method.
 	Types []string `json:"types,omitempty"`
-	
+
 	// Fields to include for each resource type.
 	Include []string `json:"include,omitempty"`
-	
+
 	// Fields to exclude for each resource type.
 	Exclude []string `json:"exclude,omitempty"`
-	
+
 	// Relationships to return for each resource.
 	// For example: artists, genres, stations.
 	// Multiple relationship types can be comma-separated.
 	Extend []string `json:"extend,omitempty"`
+
+	// Additional result sections to return.
+	// For example: topResults, which populates the top results section.
+	With []string `json:"with,omitempty"`
+
+	// Content restrictions to apply to the results.
+	// For example: explicit, which excludes explicit content.
+	Restrict []string `json:"restrict,omitempty"`
+
+	// The attributes to return for each resource type, sent as fields[type].
+	Fields map[string][]string `json:"fields,omitempty"`
+
+	// The limit for individual resource types, sent as limit[type], for
+	// example {"songs": 25, "artists": 5}. Where Apple ignores per-type
+	// limits, Limit applies to every type.
+	LimitByType map[string]int `json:"limitByType,omitempty"`
 }
 
 // DefaultSearchLimit is the default limit for search results.
@@ -80,11 +221,132 @@ const DefaultSearchLimit = 25
 // DefaultSearchOffset is the default offset for search results.
 const DefaultSearchOffset = 0
 
+// SearchOptionsBuilder builds SearchOptions fluently, for example
+//
+//	options := models.NewSearchOptions().WithLimit(25).WithTypes("songs", "albums").Build()
+//
+// SearchOptions can still be constructed directly.
+type SearchOptionsBuilder struct {
+	options SearchOptions
+}
+
+// NewSearchOptions returns a builder for empty search options.
+func NewSearchOptions() *SearchOptionsBuilder {
+	return &SearchOptionsBuilder{}
+}
+
+// WithLimit sets the limit for each type.
+func (b *SearchOptionsBuilder) WithLimit(limit int) *SearchOptionsBuilder {
+	b.options.Limit = limit
+	return b
+}
+
+// WithOffset sets the offset for each type.
+func (b *SearchOptionsBuilder) WithOffset(offset int) *SearchOptionsBuilder {
+	b.options.Offset = offset
+	return b
+}
+
+// WithStorefront sets the storefront.
+func (b *SearchOptionsBuilder) WithStorefront(storefront string) *SearchOptionsBuilder {
+	b.options.Storefront = storefront
+	return b
+}
+
+// WithLanguage sets the language tag.
+func (b *SearchOptionsBuilder) WithLanguage(languageTag string) *SearchOptionsBuilder {
+	b.options.LanguageTag = languageTag
+	return b
+}
+
+// WithTypes appends types to search.
+func (b *SearchOptionsBuilder) WithTypes(types ...string) *SearchOptionsBuilder {
+	b.options.Types = append(b.options.Types, types...)
+	return b
+}
+
+// WithInclude appends relationships to include.
+func (b *SearchOptionsBuilder) WithInclude(include ...string) *SearchOptionsBuilder {
+	b.options.Include = append(b.options.Include, include...)
+	return b
+}
+
+// WithExclude appends relationships to exclude.
+func (b *SearchOptionsBuilder) WithExclude(exclude ...string) *SearchOptionsBuilder {
+	b.options.Exclude = append(b.options.Exclude, exclude...)
+	return b
+}
+
+// WithExtend appends extended attributes to return.
+func (b *SearchOptionsBuilder) WithExtend(extend ...string) *SearchOptionsBuilder {
+	b.options.Extend = append(b.options.Extend, extend...)
+	return b
+}
+
+// WithSections appends additional result sections to return, such as
+// "topResults", sent as with.
+func (b *SearchOptionsBuilder) WithSections(sections ...string) *SearchOptionsBuilder {
+	b.options.With = append(b.options.With, sections...)
+	return b
+}
+
+// WithRestrict appends content restrictions, such as "explicit".
+func (b *SearchOptionsBuilder) WithRestrict(restrict ...string) *SearchOptionsBuilder {
+	b.options.Restrict = append(b.options.Restrict, restrict...)
+	return b
+}
+
+// WithFields sets the attributes to return for a resource type.
+func (b *SearchOptionsBuilder) WithFields(resourceType string, fields ...string) *SearchOptionsBuilder {
+	if b.options.Fields == nil {
+		b.options.Fields = make(map[string][]string)
+	}
+	b.options.Fields[resourceType] = fields
+	return b
+}
+
+// WithLimitForType sets the limit for a single resource type.
+func (b *SearchOptionsBuilder) WithLimitForType(resourceType string, limit int) *SearchOptionsBuilder {
+	if b.options.LimitByType == nil {
+		b.options.LimitByType = make(map[string]int)
+	}
+	b.options.LimitByType[resourceType] = limit
+	return b
+}
+
+// Build returns the options. The builder can be reused; later changes to it do
+// not affect options already built.
+func (b *SearchOptionsBuilder) Build() *SearchOptions {
+	options := b.options
+	options.Types = append([]string(nil), b.options.Types...)
+	options.Include = append([]string(nil), b.options.Include...)
+	options.Exclude = append([]string(nil), b.options.Exclude...)
+	options.Extend = append([]string(nil), b.options.Extend...)
+	options.With = append([]string(nil), b.options.With...)
+	options.Restrict = append([]string(nil), b.options.Restrict...)
+
+	if b.options.Fields != nil {
+		options.Fields = make(map[string][]string, len(b.options.Fields))
+		for resourceType, fields := range b.options.Fields {
+			options.Fields[resourceType] = append([]string(nil), fields...)
+		}
+	}
+
+	if b.options.LimitByType != nil {
+		options.LimitByType = make(map[string]int, len(b.options.LimitByType))
+		for resourceType, limit := range b.options.LimitByType {
+			options.LimitByType[resourceType] = limit
+		}
+	}
+
+	return &options
+}
+
 // MusicVideosResponse represents a music videos response.
 type MusicVideosResponse struct {
 	Data []MusicVideo `json:"data,omitempty"`
-	Href string `json:"href,omitempty"`
-	Next string `json:"next,omitempty"`
+	Href string       `json:"href,omitempty"`
+	Next string       `json:"next,omitempty"`
 }
 
 // MusicVideo represents a music video.
@@ -96,139 +358,234 @@ type MusicVideo struct {
 
 // MusicVideoAttributes represents attributes of a music video.
 type MusicVideoAttributes struct {
-	ArtistName string `json:"artistName,omitempty"`
-	Artwork    Artwork `json:"artwork,omitempty"`
-	ContentRating string `json:"contentRating,omitempty"`
-	DurationInMillis int64 `json:"durationInMillis,omitempty"`
-	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
-	GenreNames []string `json:"genreNames,omitempty"`
-	ISRC string `json:"isrc,omitempty"`
-	Name string `json:"name,omitempty"`
-	PlayParams PlayParameters `json:"playParams,omitempty"`
-	PreviewURL string `json:"previewUrl,omitempty"`
-	ReleaseDate string `json:"releaseDate,omitempty"`
-	TrackNumber int `json:"trackNumber,omitempty"`
-	URL string `json:"url,omitempty"`
-	VideoSubType string `json:"videoSubType,omitempty"`
+	ArtistName       string         `json:"artistName,omitempty"`
+	Artwork          Artwork        `json:"artwork,omitempty"`
+	ContentRating    string         `json:"contentRating,omitempty"`
+	DurationInMillis int64          `json:"durationInMillis,omitempty"`
+	EditorialNotes   EditorialNotes `json:"editorialNotes,omitempty"`
+	GenreNames       []string       `json:"genreNames,omitempty"`
+	ISRC             string         `json:"isrc,omitempty"`
+	Name             string         `json:"name,omitempty"`
+	PlayParams       PlayParameters `json:"playParams,omitempty"`
+	PreviewURL       string         `json:"previewUrl,omitempty"`
+	ReleaseDate      string         `json:"releaseDate,omitempty"`
+	TrackNumber      int            `json:"trackNumber,omitempty"`
+	URL              string         `json:"url,omitempty"`
+	VideoSubType     string         `json:"videoSubType,omitempty"`
+}
+
+// Rating returns the music video's content rating.
+func (v *MusicVideo) Rating() ContentRating {
+	return ParseContentRating(v.Attributes.ContentRating)
+}
+
+// IsAvailable reports whether the music video is playable in the storefront it
+// was fetched from. Music videos unavailable in a storefront may be returned
+// as placeholders without a name or play parameters.
+func (v *MusicVideo) IsAvailable() bool {
+	return v.Attributes.Name != "" && v.Attributes.PlayParams.ID != ""
 }
 
 // StationsResponse represents a stations response.
 type StationsResponse struct {
 	Data []Station `json:"data,omitempty"`
-	Href string `json:"href,omitempty"`
-	Next string `json:"next,omitempty"`
+	Href string    `json:"href,omitempty"`
+	Next string    `json:"next,omitempty"`
 }
 
 // Station represents a station.
 type Station struct {
 	Resource
-	Attributes    StationAttributes    `json:"attributes,omitempty"`
+	Attributes    StationAttributes       `json:"attributes,omitempty"`
 	Relationships map[string]Relationship `json:"relationships,omitempty"`
 }
 
+// StationRelationships represents the known relationships of a station.
+type StationRelationships struct {
+	// The radio show the station belongs to, if any.
+	RadioShow Relationship
+}
+
+// TypedRelationships returns the known relationships of the station from
+// Relationships.
+func (s *Station) TypedRelationships() StationRelationships {
+	return StationRelationships{
+		RadioShow: s.Relationships["radio-show"],
+	}
+}
+
+// RadioShowID returns the ID of the radio show the station belongs to, or an
+// empty string if the relationship was not returned.
+func (s *Station) RadioShowID() string {
+	radioShow := s.TypedRelationships().RadioShow
+	if len(radioShow.Data) == 0 {
+		return ""
+	}
+	return radioShow.Data[0].ID
+}
+
 // StationAttributes represents attributes of a station.
 type StationAttributes struct {
-	Artwork     Artwork        `json:"artwork,omitempty"`
+	Artwork        Artwork        `json:"artwork,omitempty"`
 	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
-	IsLive      bool           `json:"isLive,omitempty"`
-	Name        string         `json:"name,omitempty"`
-	PlayParams  PlayParameters `json:"playParams,omitempty"`
-	URL         string         `json:"url,omitempty"`
+	IsLive         bool           `json:"isLive,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	PlayParams     PlayParameters `json:"playParams,omitempty"`
+	URL            string         `json:"url,omitempty"`
 }
 
 // TopResultsResponse represents top results.
 type TopResultsResponse struct {
 	Data []Resource `json:"data,omitempty"`
-	Href string `json:"href,omitempty"`
-	Next string `json:"next,omitempty"`
+	Href string     `json:"href,omitempty"`
+	Next string     `json:"next,omitempty"`
 }
 
 // CuratorsResponse represents a curators response.
 type CuratorsResponse struct {
 	Data []Curator `json:"data,omitempty"`
-	Href string `json:"href,omitempty"`
-	Next string `json:"next,omitempty"`
+	Href string    `json:"href,omitempty"`
+	Next string    `json:"next,omitempty"`
 }
 
 // Curator represents a curator.
 type Curator struct {
 	Resource
-	Attributes    CuratorAttributes    `json:"attributes,omitempty"`
+	Attributes    CuratorAttributes       `json:"attributes,omitempty"`
 	Relationships map[string]Relationship `json:"relationships,omitempty"`
 }
 
 // CuratorAttributes represents attributes of a curator.
 type CuratorAttributes struct {
-	Artwork       Artwork        `json:"artwork,omitempty"`
+	Artwork        Artwork        `json:"artwork,omitempty"`
 	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
-	Name          string         `json:"name,omitempty"`
-	URL           string         `json:"url,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	URL            string         `json:"url,omitempty"`
 }
 
 // RadioStationsResponse represents a radio stations response.
 type RadioStationsResponse struct {
 	Data []RadioStation `json:"data,omitempty"`
-	Href string `json:"href,omitempty"`
-	Next string `json:"next,omitempty"`
+	Href string         `json:"href,omitempty"`
+	Next string         `json:"next,omitempty"`
 }
 
 // RadioStation represents a radio station.
 type RadioStation struct {
 	Resource
-	Attributes    RadioStationAttributes    `json:"attributes,omitempty"`
+	Attributes    RadioStationAttributes  `json:"attributes,omitempty"`
 	Relationships map[string]Relationship `json:"relationships,omitempty"`
 }
 
 // RadioStationAttributes represents attributes of a radio station.
 type RadioStationAttributes struct {
-	Artwork       Artwork        `json:"artwork,omitempty"`
+	Artwork        Artwork        `json:"artwork,omitempty"`
 	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
-	IsLive        bool           `json:"isLive,omitempty"`
-	Name          string         `json:"name,omitempty"`
-	PlayParams    PlayParameters `json:"playParams,omitempty"`
-	URL           string         `json:"url,omitempty"`
+	IsLive         bool           `json:"isLive,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	PlayParams     PlayParameters `json:"playParams,omitempty"`
+	URL            string         `json:"url,omitempty"`
 }
 
 // AppleCuratorsResponse represents an apple curators response.
 type AppleCuratorsResponse struct {
 	Data []AppleCurator `json:"data,omitempty"`
-	Href string `json:"href,omitempty"`
-	Next string `json:"next,omitempty"`
+	Href string         `json:"href,omitempty"`
+	Next string         `json:"next,omitempty"`
 }
 
 // AppleCurator represents an apple curator.
 type AppleCurator struct {
 	Resource
-	Attributes    AppleCuratorAttributes    `json:"attributes,omitempty"`
+	Attributes    AppleCuratorAttributes  `json:"attributes,omitempty"`
 	Relationships map[string]Relationship `json:"relationships,omitempty"`
 }
 
 // AppleCuratorAttributes represents attributes of an apple curator.
 type AppleCuratorAttributes struct {
-	Artwork       Artwork        `json:"artwork,omitempty"`
+	Artwork        Artwork        `json:"artwork,omitempty"`
 	EditorialNotes EditorialNotes `json:"editorialNotes,omitempty"`
-	Name          string         `json:"name,omitempty"`
-	URL           string         `json:"url,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	URL            string         `json:"url,omitempty"`
 }
 
 // RecordLabelsResponse represents a record labels response.
 type RecordLabelsResponse struct {
 	Data []RecordLabel `json:"data,omitempty"`
-	Href string `json:"href,omitempty"`
-	Next string `json:"next,omitempty"`
+	Href string        `json:"href,omitempty"`
+	Next string        `json:"next,omitempty"`
 }
 
 // RecordLabel represents a record label.
 type RecordLabel struct {
 	Resource
-	Attributes    RecordLabelAttributes    `json:"attributes,omitempty"`
+	Attributes    RecordLabelAttributes   `json:"attributes,omitempty"`
 	Relationships map[string]Relationship `json:"relationships,omitempty"`
 }
 
 // RecordLabelAttributes represents attributes of a record label.
 type RecordLabelAttributes struct {
-	Artwork       Artwork        `json:"artwork,omitempty"`
-	Description   string         `json:"description,omitempty"`
-	Name          string         `json:"name,omitempty"`
-	URL           string         `json:"url,omitempty"`
-}
\ No newline at end of file
+	Artwork     Artwork `json:"artwork,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	URL         string  `json:"url,omitempty"`
+}
+
+// SearchSuggestions represents suggestions for a partial search term, as
+// returned by the search suggestions endpoint.
+type SearchSuggestions struct {
+	// The suggested search terms.
+	Terms []TermSuggestion `json:"terms,omitempty"`
+
+	// The suggested resources, decoded by type with DecodeResource.
+	TopResults []interface{} `json:"topResults,omitempty"`
+}
+
+// TermSuggestion represents a suggested search term.
+type TermSuggestion struct {
+	// The term to search for when the suggestion is chosen.
+	SearchTerm string `json:"searchTerm"`
+
+	// The term to display for the suggestion.
+	DisplayTerm string `json:"displayTerm"`
+}
+
+// DecodeResource decodes a single resource according to its type field. The
+// result is a *Song, *Album, *Artist, *Playlist, *MusicVideo, *Station,
+// *Curator, *AppleCurator, or *RecordLabel, or a *Resource for other types.
+func DecodeResource(data []byte) (interface{}, error) {
+	var resource Resource
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	switch ResourceType(resource.Type) {
+	case ResourceTypeSongs:
+		v = &Song{}
+	case ResourceTypeAlbums:
+		v = &Album{}
+	case ResourceTypeArtists:
+		v = &Artist{}
+	case ResourceTypePlaylists:
+		v = &Playlist{}
+	case ResourceTypeMusicVideos:
+		v = &MusicVideo{}
+	case ResourceTypeStations:
+		v = &Station{}
+	case ResourceTypeCurators:
+		v = &Curator{}
+	case ResourceTypeAppleCurators:
+		v = &AppleCurator{}
+	case ResourceTypeRecordLabels:
+		v = &RecordLabel{}
+	default:
+		return &resource, nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
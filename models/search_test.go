@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSearchOptionsBuilder(t *testing.T) {
+	builder := NewSearchOptions().
+		WithLimit(10).
+		WithOffset(20).
+		WithStorefront("gb").
+		WithLanguage("en-GB").
+		WithTypes("songs").
+		WithTypes("albums").
+		WithInclude("artists").
+		WithExclude("tracks").
+		WithExtend("artistUrl").
+		WithSections("topResults").
+		WithRestrict("explicit").
+		WithFields("songs", "name", "artistName").
+		WithLimitForType("albums", 5)
+
+	want := &SearchOptions{
+		Limit:       10,
+		Offset:      20,
+		Storefront:  "gb",
+		LanguageTag: "en-GB",
+		Types:       []string{"songs", "albums"},
+		Include:     []string{"artists"},
+		Exclude:     []string{"tracks"},
+		Extend:      []string{"artistUrl"},
+		With:        []string{"topResults"},
+		Restrict:    []string{"explicit"},
+		Fields:      map[string][]string{"songs": {"name", "artistName"}},
+		LimitByType: map[string]int{"albums": 5},
+	}
+
+	options := builder.Build()
+	if !reflect.DeepEqual(options, want) {
+		t.Fatalf("Build() = %+v, want %+v", options, want)
+	}
+
+	builder.WithTypes("artists").WithFields("albums", "name").WithLimitForType("songs", 1)
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("options changed after reusing the builder: %+v", options)
+	}
+}
+
+func TestSearchResultsAttribution(t *testing.T) {
+	data := `{"meta":{"results":{"order":["top","songs","albums"],"rawOrder":["songs","albums","top"]},` +
+		`"metrics":{"dataSetId":"00000003"},"experimental":true},` +
+		`"results":{"songs":{"data":[{"id":"1","type":"songs"}]}}}`
+
+	var results SearchResults
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	attribution := results.Attribution()
+	if want := []string{"top", "songs", "albums"}; !reflect.DeepEqual(attribution.Order, want) || !reflect.DeepEqual(results.Order(), want) {
+		t.Errorf("Order = %v, %v, want %v", attribution.Order, results.Order(), want)
+	}
+	if want := []string{"songs", "albums", "top"}; !reflect.DeepEqual(attribution.RawOrder, want) {
+		t.Errorf("RawOrder = %v, want %v", attribution.RawOrder, want)
+	}
+	if attribution.DataSetID != "00000003" {
+		t.Errorf("DataSetID = %q, want %q", attribution.DataSetID, "00000003")
+	}
+	if attribution.Raw["experimental"] != true {
+		t.Errorf("Raw = %v, want the unknown experimental field kept", attribution.Raw)
+	}
+
+	var empty SearchResults
+	if err := json.Unmarshal([]byte(`{"results":{}}`), &empty); err != nil {
+		t.Fatalf("Unmarshal() without meta error = %v", err)
+	}
+	if attribution := empty.Attribution(); attribution.Order != nil || attribution.DataSetID != "" {
+		t.Errorf("Attribution() without meta = %+v, want empty fields", attribution)
+	}
+}
+
+func TestStationRelationships(t *testing.T) {
+	data := `{"id":"ra.1","type":"stations","relationships":{` +
+		`"radio-show":{"data":[{"id":"ra.show","type":"radio-shows"}]},` +
+		`"episodes":{"data":[{"id":"ra.ep","type":"stations"}]}}}`
+
+	var station Station
+	if err := json.Unmarshal([]byte(data), &station); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got := station.RadioShowID(); got != "ra.show" {
+		t.Errorf("RadioShowID() = %q, want %q", got, "ra.show")
+	}
+	if _, ok := station.Relationships["episodes"]; !ok {
+		t.Errorf("Relationships = %v, want the episodes relationship kept", station.Relationships)
+	}
+
+	var empty Station
+	if got := empty.RadioShowID(); got != "" {
+		t.Errorf("RadioShowID() without relationships = %q, want empty", got)
+	}
+}
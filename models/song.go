@@ -22,9 +22,18 @@ type SongAttributes struct {
 	// The artist name.
 	ArtistName string `json:"artistName"`
 
+	// The URL of the artist, when requested with extend.
+	ArtistURL string `json:"artistUrl,omitempty"`
+
 	// The song artwork.
 	Artwork Artwork `json:"artwork"`
 
+	// The audio traits, such as "lossless" or "atmos".
+	AudioTraits []string `json:"audioTraits,omitempty"`
+
+	// The audio variants, when requested with extend.
+	AudioVariants []string `json:"audioVariants,omitempty"`
+
 	// Whether the song is a composer.
 	Composer string `json:"composer,omitempty"`
 
@@ -46,6 +55,10 @@ type SongAttributes struct {
 	// Whether the song has lyrics.
 	HasLyrics bool `json:"hasLyrics"`
 
+	// Whether the song has time-synced lyrics. This attribute may only be
+	// returned when requested with extend=hasTimeSyncedLyrics.
+	HasTimeSyncedLyrics bool `json:"hasTimeSyncedLyrics,omitempty"`
+
 	// Whether the song is Apple Digital Master.
 	IsAppleDigitalMaster bool `json:"isAppleDigitalMaster,omitempty"`
 
@@ -107,9 +120,14 @@ type SongsResponse struct {
 	Next string `json:"next,omitempty"`
 }
 
+// Pagination returns the pagination information from the response's meta and next link.
+func (r *SongsResponse) Pagination() Pagination {
+	return PaginationFromMeta(r.Meta, r.Next)
+}
+
 // GetArtworkURL returns the URL for the song artwork with the specified dimensions.
 func (s *Song) GetArtworkURL(width, height int) string {
-	return s.Attributes.Artwork.URL
+	return s.Attributes.Artwork.URLForSize(width, height)
 }
 
 // GetPreviewURL returns the URL for the first playable preview of the song.
@@ -123,19 +141,103 @@ func (s *Song) GetPreviewURL() string {
 		}
 		return ""
 	}
-	
+
 	// Find the first playable preview
 	for _, preview := range s.Attributes.Previews {
 		if preview.Playable {
 			return preview.URL
 		}
 	}
-	
+
 	// If no playable preview found, return the first preview URL
 	return s.Attributes.Previews[0].URL
 }
 
+// HasTimeSyncedLyrics reports whether the song is known to have time-synced lyrics.
+// It returns false when the attribute was not returned, so fetch the song with
+// Extend: []string{"hasTimeSyncedLyrics"} to get a reliable answer. A false
+// result for an instrumental track avoids a needless lyrics request.
+func (s *Song) HasTimeSyncedLyrics() bool {
+	return s.Attributes.HasLyrics && s.Attributes.HasTimeSyncedLyrics
+}
+
+// ArtistIDs returns the IDs of the song's artists from its artists relationship.
+// The relationship is only populated when the song was fetched with its artists
+// included, as catalog songs are by default; otherwise the result is empty.
+// Use CatalogService.GetSongArtists to fetch the artists themselves.
+func (s *Song) ArtistIDs() []string {
+	return s.Relationships.Artists.IDs()
+}
+
+// ComposerIDs returns the IDs of the song's composers from its composers relationship.
+func (s *Song) ComposerIDs() []string {
+	return s.Relationships.Composers.IDs()
+}
+
+// AlbumIDs returns the IDs of the song's albums from its albums relationship.
+// Like ArtistIDs, it is empty unless the relationship was returned.
+// Use CatalogService.GetSongAlbums to fetch the albums themselves.
+func (s *Song) AlbumIDs() []string {
+	return s.Relationships.Albums.IDs()
+}
+
+// AlbumID returns the ID of the song's album from its albums relationship, or
+// an empty string if the relationship was not returned. It saves a round trip
+// when the albums relationship was included in the response.
+func (s *Song) AlbumID() string {
+	if len(s.Relationships.Albums.Data) == 0 {
+		return ""
+	}
+	return s.Relationships.Albums.Data[0].ID
+}
+
+// HasISRC reports whether the song has an ISRC.
+func (s *Song) HasISRC() bool {
+	return s.Attributes.ISRC != ""
+}
+
+// IsValidISRC reports whether isrc is in the International Standard Recording
+// Code format, 12 letters and digits such as "USUM71703861". Hyphens are not
+// accepted.
+func IsValidISRC(isrc string) bool {
+	if len(isrc) != 12 {
+		return false
+	}
+
+	for _, r := range isrc {
+		if !('0' <= r && r <= '9') && !('A' <= r && r <= 'Z') && !('a' <= r && r <= 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// Rating returns the song's content rating.
+func (s *Song) Rating() ContentRating {
+	return ParseContentRating(s.Attributes.ContentRating)
+}
+
+// IsAvailable reports whether the song is playable in the storefront it was
+// fetched from. Songs unavailable in a storefront may be returned as
+// placeholders without a name or play parameters.
+func (s *Song) IsAvailable() bool {
+	return s.Attributes.Name != "" && s.Attributes.PlayParams.ID != ""
+}
+
 // FormatReleaseDate formats the release date as a time.Time.
 func (s *Song) FormatReleaseDate() (time.Time, error) {
 	return time.Parse("2006-01-02", s.Attributes.ReleaseDate)
-}
\ No newline at end of file
+}
+
+// SupportsLossless reports whether the song is available in lossless or
+// high-resolution lossless audio.
+func (s *Song) SupportsLossless() bool {
+	return hasAudioQuality(s.Attributes.AudioTraits, s.Attributes.AudioVariants,
+		AudioTraitLossless, AudioTraitHiResLossless)
+}
+
+// SupportsAtmos reports whether the song is available in Dolby Atmos.
+func (s *Song) SupportsAtmos() bool {
+	return hasAudioQuality(s.Attributes.AudioTraits, s.Attributes.AudioVariants,
+		AudioTraitAtmos, AudioVariantDolbyAtmos)
+}
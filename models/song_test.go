@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSongIsAvailable(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want bool
+	}{
+		{"available", `{"id":"1","type":"songs","attributes":{"name":"Song","playParams":{"id":"1","kind":"song"}}}`, true},
+		{"placeholder", `{"id":"1","type":"songs","attributes":{}}`, false},
+		{"placeholder without attributes", `{"id":"1","type":"songs"}`, false},
+		{"no play parameters", `{"id":"1","type":"songs","attributes":{"name":"Song"}}`, false},
+	}
+
+	for _, tt := range tests {
+		var song Song
+		if err := json.Unmarshal([]byte(tt.json), &song); err != nil {
+			t.Fatalf("%s: failed to decode song: %v", tt.name, err)
+		}
+
+		if got := song.IsAvailable(); got != tt.want {
+			t.Errorf("%s: IsAvailable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsValidISRC(t *testing.T) {
+	tests := []struct {
+		isrc string
+		want bool
+	}{
+		{"USUM71703861", true},
+		{"gbaye0601498", true},
+		{"USMOCK0000001", false},
+		{"USUM7170386", false},
+		{"US-UM7-17-03861", false},
+		{"USUM 1703861", false},
+		{"USUM7170386Ä", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidISRC(tt.isrc); got != tt.want {
+			t.Errorf("IsValidISRC(%q) = %v, want %v", tt.isrc, got, tt.want)
+		}
+	}
+}
@@ -3,11 +3,17 @@
 package musickitkat
 
 import (
+	"context"
+	stderrors "errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/marcusziade/musickitkat/auth"
 	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/errors"
 	"github.com/marcusziade/musickitkat/services"
 )
 
@@ -24,6 +30,12 @@ type Client struct {
 	DeveloperToken string
 	UserToken      string
 
+	// Developer token the client was created with, for StartTokenRefresh
+	developerToken *auth.DeveloperToken
+
+	// Whether a StartTokenRefresh goroutine is running
+	refreshing atomic.Bool
+
 	// Services for interacting with different parts of the Apple Music API
 	Catalog         *services.CatalogService
 	Library         *services.LibraryService
@@ -31,6 +43,13 @@ type Client struct {
 	Search          *services.SearchService
 	Recommendations *services.RecommendationService
 	Radio           *services.RadioService
+	Ratings         *services.RatingService
+
+	// Storefronts the catalog retries not-found resources in
+	storefrontFallback []string
+
+	// First error reported by an option during construction
+	initErr error
 }
 
 // ClientOption is a function that configures a Client.
@@ -43,10 +62,19 @@ func WithHTTPClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// WithBaseURL sets the base URL of the API, for example the URL of a
+// mockapi.Server in tests.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.httpClient.SetBaseURL(baseURL)
+	}
+}
+
 // WithDeveloperToken sets the developer token.
 func WithDeveloperToken(token *auth.DeveloperToken) ClientOption {
 	return func(c *Client) {
 		c.DeveloperToken = token.String()
+		c.developerToken = token
 		c.httpClient.SetDeveloperToken(token.String())
 	}
 }
@@ -59,6 +87,85 @@ func WithUserToken(token string) ClientOption {
 	}
 }
 
+// WithUserTokenFromManager sets the user token to the given user's cached token,
+// refreshing it through the manager if it has expired. If the user has no cached
+// token, or the refresh fails, New returns the error.
+func WithUserTokenFromManager(ctx context.Context, manager *auth.UserTokenManager, userID string) ClientOption {
+	return func(c *Client) {
+		token, err := manager.GetUserToken(ctx, userID)
+		if err != nil {
+			c.setInitErr(fmt.Errorf("failed to get user token for %s: %w", userID, err))
+			return
+		}
+
+		c.UserToken = token.AccessToken
+		c.httpClient.SetUserToken(token.AccessToken)
+	}
+}
+
+// WithUserTokenHeader sets the name of the header used to send the user token.
+// The default is client.DefaultUserTokenHeader, "Music-User-Token".
+func WithUserTokenHeader(name string) ClientOption {
+	return func(c *Client) {
+		c.httpClient.SetUserTokenHeader(name)
+	}
+}
+
+// WithForceHTTP1 makes the client use HTTP/1.1 instead of HTTP/2, which can
+// help behind proxies that break HTTP/2 connections, at the cost of one
+// connection per concurrent request. HTTP/2 is used by default. Apply it after
+// WithHTTPClient; New returns an error if the HTTP client's transport cannot be
+// configured.
+func WithForceHTTP1(force bool) ClientOption {
+	return func(c *Client) {
+		if err := c.httpClient.SetForceHTTP1(force); err != nil {
+			c.setInitErr(fmt.Errorf("failed to configure HTTP version: %w", err))
+		}
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the server's TLS
+// certificate, for tests against a local HTTPS mock with a self-signed
+// certificate. It is off by default and UNSAFE in production: anyone who can
+// intercept the connection can read the tokens. Apply it after WithHTTPClient;
+// New returns an error if the HTTP client's transport cannot be configured.
+func WithInsecureSkipVerify(skip bool) ClientOption {
+	return func(c *Client) {
+		if err := c.httpClient.SetInsecureSkipVerify(skip); err != nil {
+			c.setInitErr(fmt.Errorf("failed to configure TLS verification: %w", err))
+		}
+	}
+}
+
+// WithETagCache enables conditional GET requests with If-None-Match, so that
+// unchanged resources are answered with 304 Not Modified and decoded from an
+// in-memory cache. Use GetConditional to learn whether a result came from the
+// cache.
+func WithETagCache() ClientOption {
+	return func(c *Client) {
+		c.httpClient.SetETagCaching(true)
+	}
+}
+
+// WithRequestIDFunc attaches a client-generated request ID to every request, in
+// the X-Request-ID header and in the client's log lines, to correlate SDK logs
+// with application logs. IDs are generated by fn, or are UUIDs if fn is nil.
+func WithRequestIDFunc(fn func() string) ClientOption {
+	return func(c *Client) {
+		c.httpClient.SetRequestIDFunc(fn)
+	}
+}
+
+// WithStorefrontFallback makes the catalog getters of single resources by ID
+// retry in the given storefronts, in order, when a resource is not available in
+// the requested storefront. Storefront on the result reports which storefront
+// served it. There is no fallback by default.
+func WithStorefrontFallback(storefronts ...string) ClientOption {
+	return func(c *Client) {
+		c.storefrontFallback = storefronts
+	}
+}
+
 // WithTimeout sets the request timeout.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
@@ -73,8 +180,27 @@ func WithLogLevel(level LogLevel) ClientOption {
 	}
 }
 
+// WithDebugBodyLimit sets the maximum number of bytes of a request or response
+// body written to logs at LogLevelDebug. Longer bodies are truncated, and
+// non-text bodies are omitted. The default is client.DefaultDebugBodyLimit; a
+// limit of zero or less logs bodies in full.
+func WithDebugBodyLimit(n int) ClientOption {
+	return func(c *Client) {
+		c.httpClient.SetDebugBodyLimit(n)
+	}
+}
+
 // NewClient creates a new MusicKitKat client with the provided options.
+// Errors reported by options such as WithUserTokenFromManager are ignored;
+// use New to observe them.
 func NewClient(options ...ClientOption) *Client {
+	c, _ := New(options...)
+	return c
+}
+
+// New creates a new MusicKitKat client with the provided options and returns
+// the first error reported by an option.
+func New(options ...ClientOption) (*Client, error) {
 	httpClient := client.NewClient()
 
 	c := &Client{
@@ -93,8 +219,110 @@ func NewClient(options ...ClientOption) *Client {
 	c.Search = services.NewSearchService(c.httpClient)
 	c.Recommendations = services.NewRecommendationService(c.httpClient)
 	c.Radio = services.NewRadioService(c.httpClient)
+	c.Ratings = services.NewRatingService(c.httpClient)
 
-	return c
+	// Apply options that configure services
+	if len(c.storefrontFallback) > 0 {
+		c.Catalog.SetStorefrontFallback(c.storefrontFallback...)
+	}
+
+	return c, c.initErr
+}
+
+// Ping verifies that the developer token is accepted by the Apple Music API by
+// fetching a single storefront. It returns nil on success, or an error that
+// wraps the *errors.APIError, which can be classified with the errors package
+// (for example with errors.IsAuthenticationError).
+func (c *Client) Ping(ctx context.Context) error {
+	var response struct {
+		Data []interface{} `json:"data"`
+	}
+
+	return c.httpClient.Get(ctx, "storefronts/us", &response)
+}
+
+// GetConditional sends a GET request for path, relative to the API version,
+// and decodes the response into result. It reports whether the result was
+// decoded from the ETag cache after a 304 Not Modified response, which only
+// happens when the client was created with WithETagCache.
+func (c *Client) GetConditional(ctx context.Context, path string, result interface{}) (fromCache bool, err error) {
+	return c.httpClient.GetConditional(ctx, path, result)
+}
+
+// StartTokenRefresh starts a goroutine that checks the developer token's expiry
+// immediately and then every interval and, when the token would expire within
+// two intervals, regenerates it from the configuration it was created with and
+// switches the client over to the new token. Refreshes and failures are logged;
+// a failed refresh is retried at the next check. The goroutine stops when ctx
+// is cancelled, after which the refresh can be started again; starting it while
+// it is running returns an error. The client must have been created with
+// WithDeveloperToken. The DeveloperToken field keeps the token the client was
+// created with.
+func (c *Client) StartTokenRefresh(ctx context.Context, interval time.Duration) error {
+	if c.developerToken == nil {
+		return fmt.Errorf("token refresh requires a developer token set with WithDeveloperToken")
+	}
+
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive: %s", interval)
+	}
+
+	if !c.refreshing.CompareAndSwap(false, true) {
+		return fmt.Errorf("token refresh is already running")
+	}
+
+	token := c.developerToken
+	go func() {
+		defer c.refreshing.Store(false)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			token = c.refreshDeveloperToken(token, 2*interval)
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// refreshDeveloperToken returns a new developer token, set on the HTTP client,
+// if token expires within margin, and token otherwise or if the refresh fails.
+func (c *Client) refreshDeveloperToken(token *auth.DeveloperToken, margin time.Duration) *auth.DeveloperToken {
+	expiring, err := token.ExpiresWithin(margin)
+	if err != nil {
+		c.httpClient.Logf(client.LogLevelError, "Failed to read developer token expiry: %v", err)
+		return token
+	}
+
+	if !expiring {
+		return token
+	}
+
+	refreshed, err := token.Refresh()
+	if err != nil {
+		c.httpClient.Logf(client.LogLevelError, "Failed to refresh developer token: %v", err)
+		return token
+	}
+
+	c.httpClient.SetDeveloperToken(refreshed.String())
+	if newExpiresAt, err := refreshed.ExpiresAt(); err == nil {
+		c.httpClient.Logf(client.LogLevelInfo, "Refreshed developer token; the new token expires at %s", newExpiresAt)
+	}
+	return refreshed
+}
+
+// setInitErr records the first error reported by an option.
+func (c *Client) setInitErr(err error) {
+	if c.initErr == nil {
+		c.initErr = err
+	}
 }
 
 // LogLevel defines the verbosity of client logging
@@ -126,3 +354,106 @@ const (
 	SearchTypesAppleCurators SearchTypes = "apple-curators"
 	SearchTypesRecordLabels  SearchTypes = "record-labels"
 )
+
+// ValidateUserToken verifies that the user token is accepted by the Apple Music
+// API by fetching a single song from the user's library. Unlike Ping, it checks
+// the user token rather than the developer token. It returns nil if the token
+// is valid, errors.ErrUserTokenRequired if no user token is set,
+// errors.ErrUserTokenInvalid if the token is invalid or expired, or
+// errors.ErrSubscriptionRequired if the user has no active subscription, which
+// is how any 403 Forbidden from the library is reported. The last two wrap the
+// underlying API error.
+func (c *Client) ValidateUserToken(ctx context.Context) error {
+	if !c.httpClient.HasUserTokenFor(ctx) {
+		return errors.ErrUserTokenRequired
+	}
+
+	var response struct {
+		Data []interface{} `json:"data"`
+	}
+
+	// The library is refused with 403 Forbidden without a subscription,
+	// whatever the error text says
+	return userTokenError(c.httpClient.Get(ctx, "me/library/songs?limit=1", &response), errors.ErrSubscriptionRequired)
+}
+
+// SubscriptionStatus describes the user's Apple Music subscription.
+type SubscriptionStatus struct {
+	// Whether the user has an active subscription, and so can stream catalog
+	// content and access their library.
+	Active bool
+
+	// The ID of the user's storefront, for example "us".
+	Storefront string
+}
+
+// GetUserSubscriptionStatus determines whether the user has an active Apple
+// Music subscription by fetching the user's storefront, which succeeds for any
+// valid user token, and then probing the user's library, which is refused with
+// 403 Forbidden without a subscription. An inactive subscription is reported
+// in the status, not as an error. It returns errors.ErrUserTokenRequired if no
+// user token is set and errors.ErrUserTokenInvalid if the token is invalid or
+// expired.
+func (c *Client) GetUserSubscriptionStatus(ctx context.Context) (SubscriptionStatus, error) {
+	if !c.httpClient.HasUserTokenFor(ctx) {
+		return SubscriptionStatus{}, errors.ErrUserTokenRequired
+	}
+
+	var response struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+
+	var status SubscriptionStatus
+	err := userTokenError(c.httpClient.Get(ctx, "me/storefront", &response), errors.ErrUserTokenInvalid)
+	switch {
+	case err == nil:
+		if len(response.Data) > 0 {
+			status.Storefront = response.Data[0].ID
+		}
+	case stderrors.Is(err, errors.ErrSubscriptionRequired):
+		return status, nil
+	default:
+		return SubscriptionStatus{}, err
+	}
+
+	err = c.ValidateUserToken(ctx)
+	switch {
+	case err == nil:
+		status.Active = true
+	case stderrors.Is(err, errors.ErrSubscriptionRequired):
+	default:
+		return SubscriptionStatus{}, err
+	}
+
+	return status, nil
+}
+
+// userTokenError classifies the error of a request made with the user token,
+// wrapping authentication and authorization errors in
+// errors.ErrUserTokenInvalid or errors.ErrSubscriptionRequired. A 403 whose
+// error mentions a subscription wraps errors.ErrSubscriptionRequired; any other
+// 403 wraps forbidden. It returns nil if err is nil.
+func userTokenError(err, forbidden error) error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %w", errors.ErrUserTokenInvalid, err)
+	case http.StatusForbidden:
+		if strings.Contains(strings.ToLower(apiErr.Error()), "subscription") {
+			return fmt.Errorf("%w: %w", errors.ErrSubscriptionRequired, err)
+		}
+		return fmt.Errorf("%w: %w", forbidden, err)
+	default:
+		return err
+	}
+}
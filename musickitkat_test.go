@@ -0,0 +1,80 @@
+package musickitkat
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/errors"
+	"github.com/marcusziade/musickitkat/mockapi"
+)
+
+// newMockClient starts a mockapi server with fixtures and returns a client
+// that sends requests to it with a developer and a user token.
+func newMockClient(t *testing.T, fixtures mockapi.Fixtures) (*mockapi.Server, *Client) {
+	t.Helper()
+
+	server := mockapi.NewServer(fixtures)
+	t.Cleanup(server.Close)
+
+	c, err := New(WithBaseURL(server.URL), WithUserToken("user-token"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	c.httpClient.SetDeveloperToken("developer-token")
+	return server, c
+}
+
+func TestGetUserSubscriptionStatus(t *testing.T) {
+	const forbidden = `{"errors":[{"status":"403","title":"Forbidden","detail":"Forbidden"}]}`
+
+	tests := []struct {
+		name    string
+		library mockapi.Response
+		want    SubscriptionStatus
+	}{
+		{"active", mockapi.Response{Body: `{"data":[]}`}, SubscriptionStatus{Active: true, Storefront: "gb"}},
+		{"plain 403", mockapi.Response{Status: http.StatusForbidden, Body: forbidden}, SubscriptionStatus{Storefront: "gb"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newMockClient(t, mockapi.Fixtures{
+				"GET me/storefront":    {Body: `{"data":[{"id":"gb","type":"storefronts"}]}`},
+				"GET me/library/songs": tt.library,
+			})
+
+			status, err := c.GetUserSubscriptionStatus(context.Background())
+			if err != nil {
+				t.Fatalf("GetUserSubscriptionStatus() error = %v", err)
+			}
+			if status != tt.want {
+				t.Errorf("GetUserSubscriptionStatus() = %+v, want %+v", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateUserTokenClassifiesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{"forbidden", http.StatusForbidden, errors.ErrSubscriptionRequired},
+		{"unauthorized", http.StatusUnauthorized, errors.ErrUserTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, c := newMockClient(t, nil)
+			server.Handle("GET me/library/songs", tt.status, `{"errors":[{"status":"`+strconv.Itoa(tt.status)+`","title":"Denied"}]}`)
+
+			if err := c.ValidateUserToken(context.Background()); !stderrors.Is(err, tt.want) {
+				t.Errorf("ValidateUserToken() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -8,12 +8,45 @@ import (
 	"strings"
 
 	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/errors"
 	"github.com/marcusziade/musickitkat/models"
 )
 
 // BaseService is the base service for all API services.
 type BaseService struct {
-	client *client.Client
+	client   *client.Client
+	defaults models.QueryParameters
+	strict   bool
+
+	// Number of requests fan-out helpers run at once
+	concurrency int
+
+	// Page size of helpers that page through collections in full
+	pageSize int
+
+	// Language tag sent with every catalog request
+	language string
+}
+
+// emptyResponse is an error for a successful response that contained no data.
+type emptyResponse struct {
+	message string
+}
+
+// Error returns the error message.
+func (e *emptyResponse) Error() string {
+	return e.message
+}
+
+// Unwrap returns errors.ErrEmptyResponse, so that errors.Is matches it.
+func (e *emptyResponse) Unwrap() error {
+	return errors.ErrEmptyResponse
+}
+
+// emptyResponseError returns an error reporting that a response contained no
+// data. It matches errors.ErrEmptyResponse.
+func emptyResponseError(format string, args ...interface{}) error {
+	return &emptyResponse{message: fmt.Sprintf(format, args...)}
 }
 
 // NewBaseService creates a new BaseService with the provided client.
@@ -23,7 +56,9 @@ func NewBaseService(client *client.Client) *BaseService {
 	}
 }
 
-// buildPath builds a path with query parameters.
+// buildPath builds a path with query parameters. Query values are escaped here,
+// exactly once; identifiers in the path itself must be escaped with
+// url.PathEscape when the path is built.
 func (s *BaseService) buildPath(path string, queryParams url.Values) string {
 	if len(queryParams) == 0 {
 		return path
@@ -32,8 +67,127 @@ func (s *BaseService) buildPath(path string, queryParams url.Values) string {
 	return fmt.Sprintf("%s?%s", path, queryParams.Encode())
 }
 
-// buildQueryParams builds query parameters from a QueryParameters struct.
+// SetLanguage sets the language tag applied to every catalog request made by
+// the service as the l query parameter, so localized names are returned for
+// the storefront. A language tag passed in per-call options takes precedence.
+func (s *BaseService) SetLanguage(languageTag string) {
+	s.language = languageTag
+}
+
+// catalogPath builds a path to a resource under the given storefront's catalog,
+// applying the service's language tag unless queryParams already specifies one.
+func (s *BaseService) catalogPath(storefront, resource string, queryParams url.Values) string {
+	if queryParams == nil {
+		queryParams = url.Values{}
+	}
+
+	if s.language != "" && queryParams.Get("l") == "" {
+		queryParams.Set("l", s.language)
+	}
+
+	return s.buildPath(fmt.Sprintf("catalog/%s/%s", storefront, resource), queryParams)
+}
+
+// SetDefaultQueryParameters sets query parameters that are merged into every
+// request made by the service.
+//
+// Per-call values take precedence: a field set in per-call options, or passed
+// as an argument such as limit, overrides the default, and a field left at its
+// zero value falls back to the default. Slice fields are replaced, not
+// appended. Limit and Offset defaults only apply to requests for collections,
+// not to requests for specific resources by ID.
+func (s *BaseService) SetDefaultQueryParameters(params models.QueryParameters) {
+	s.defaults = params
+}
+
+// SetStrictValidation enables or disables strict mode. In strict mode, methods
+// that take QueryParameters reject include, extend, and views values that are
+// not listed in models.ValidIncludes, models.ValidExtends, and models.ValidViews
+// before making a request.
+func (s *BaseService) SetStrictValidation(strict bool) {
+	s.strict = strict
+}
+
+// validateQueryParams validates params merged with the service defaults for
+// the given resource type when strict mode is enabled.
+func (s *BaseService) validateQueryParams(resourceType string, params models.QueryParameters) error {
+	if !s.strict {
+		return nil
+	}
+
+	return models.ValidateQueryParameters(resourceType, s.withDefaults(params))
+}
+
+// withDefaults fills zero-valued fields of params from the service defaults.
+func (s *BaseService) withDefaults(params models.QueryParameters) models.QueryParameters {
+	if params.Limit == 0 {
+		params.Limit = s.defaults.Limit
+	}
+
+	if params.Offset == 0 {
+		params.Offset = s.defaults.Offset
+	}
+
+	if len(params.Include) == 0 {
+		params.Include = s.defaults.Include
+	}
+
+	if len(params.Exclude) == 0 {
+		params.Exclude = s.defaults.Exclude
+	}
+
+	if len(params.Extend) == 0 {
+		params.Extend = s.defaults.Extend
+	}
+
+	if len(params.Views) == 0 {
+		params.Views = s.defaults.Views
+	}
+
+	if len(params.Fields) == 0 {
+		params.Fields = s.defaults.Fields
+	}
+
+	if params.LanguageTag == "" {
+		params.LanguageTag = s.defaults.LanguageTag
+	}
+
+	if params.Storefront == "" {
+		params.Storefront = s.defaults.Storefront
+	}
+
+	return params
+}
+
+// buildQueryParams builds query parameters for a collection request from a
+// QueryParameters struct merged with the service defaults.
 func (s *BaseService) buildQueryParams(params models.QueryParameters) url.Values {
+	return s.encodeQueryParams(s.withDefaults(params))
+}
+
+// buildResourceQueryParams builds query parameters for a request for specific
+// resources from a QueryParameters struct merged with the service defaults.
+// Limit and offset defaults are not applied.
+func (s *BaseService) buildResourceQueryParams(params models.QueryParameters) url.Values {
+	merged := s.withDefaults(params)
+	merged.Limit = params.Limit
+	merged.Offset = params.Offset
+	return s.encodeQueryParams(merged)
+}
+
+// defaultQueryParams returns the service default query parameters for a collection request.
+func (s *BaseService) defaultQueryParams() url.Values {
+	return s.buildQueryParams(models.QueryParameters{})
+}
+
+// defaultResourceQueryParams returns the service default query parameters for a
+// request for specific resources.
+func (s *BaseService) defaultResourceQueryParams() url.Values {
+	return s.buildResourceQueryParams(models.QueryParameters{})
+}
+
+// encodeQueryParams encodes a QueryParameters struct as query parameters.
+func (s *BaseService) encodeQueryParams(params models.QueryParameters) url.Values {
 	queryParams := url.Values{}
 
 	if params.Limit > 0 {
@@ -52,6 +206,16 @@ func (s *BaseService) buildQueryParams(params models.QueryParameters) url.Values
 		queryParams.Set("exclude", strings.Join(params.Exclude, ","))
 	}
 
+	if len(params.Extend) > 0 {
+		queryParams.Set("extend", strings.Join(params.Extend, ","))
+	}
+
+	if len(params.Views) > 0 {
+		queryParams.Set("views", strings.Join(params.Views, ","))
+	}
+
+	setFields(params.Fields, queryParams)
+
 	if params.LanguageTag != "" {
 		queryParams.Set("l", params.LanguageTag)
 	}
@@ -84,6 +248,15 @@ func (s *BaseService) setInclude(include []string, queryParams url.Values) {
 	}
 }
 
+// setFields sets a fields[type] query parameter for each resource type.
+func setFields(fields map[string][]string, queryParams url.Values) {
+	for resourceType, names := range fields {
+		if len(names) > 0 {
+			queryParams.Set(fmt.Sprintf("fields[%s]", resourceType), strings.Join(names, ","))
+		}
+	}
+}
+
 // setTypes sets the types query parameter.
 func (s *BaseService) setTypes(types []string, queryParams url.Values) {
 	if len(types) > 0 {
@@ -2,10 +2,16 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/errors"
 	"github.com/marcusziade/musickitkat/models"
 )
 
@@ -13,6 +19,17 @@ import (
 type CatalogService struct {
 	BaseService
 	storefront string
+
+	// Genre lists by request path, and so by storefront and language, fetched
+	// once for the service's lifetime
+	genresMu sync.Mutex
+	genres   map[string]*genreList
+
+	// Storefronts to retry single-resource getters in on not-found
+	storefrontFallback []string
+
+	// Whether batch getters drop placeholders of unavailable resources
+	skipUnavailable bool
 }
 
 // NewCatalogService creates a new CatalogService with the provided client.
@@ -28,72 +45,277 @@ func (s *CatalogService) SetStorefront(storefront string) {
 	s.storefront = storefront
 }
 
+// GetStorefront returns the default storefront of the catalog service. A
+// storefront attached to a call's context with WithStorefront, or passed in
+// the call's options, takes precedence over it.
+func (s *CatalogService) GetStorefront() string {
+	return s.storefront
+}
+
+// SetStorefrontFallback sets storefronts, such as "us", that the getters of
+// single resources by ID (GetSong, GetAlbum, GetArtist, GetPlaylist, and
+// GetResource) retry in, in order, when the resource is not found in the
+// requested storefront. Use Storefront on the result to learn which storefront
+// served it. Calling it with no storefronts disables the fallback, which is
+// the default.
+func (s *CatalogService) SetStorefrontFallback(storefronts ...string) {
+	s.storefrontFallback = storefronts
+}
+
+// SetSkipUnavailable makes the batch getters (GetSongs, GetAlbums, GetArtists,
+// GetPlaylists, and their WithOptions variants) drop the placeholders Apple
+// returns for resources unavailable in the storefront, as reported by their
+// IsAvailable methods, so that they are not rendered as blank rows. They are
+// kept by default. Nothing is dropped from a call that requests sparse fields
+// for the resource type with Fields, since the name and play parameters that
+// IsAvailable checks may then be missing from available resources too.
+func (s *CatalogService) SetSkipUnavailable(skip bool) {
+	s.skipUnavailable = skip
+}
+
+// availableOnly returns the items whose IsAvailable method reports true. Items
+// of types without the method are kept.
+func availableOnly[T any](items []T) []T {
+	available := items[:0]
+	for i := range items {
+		if item, ok := interface{}(&items[i]).(interface{ IsAvailable() bool }); ok && !item.IsAvailable() {
+			continue
+		}
+		available = append(available, items[i])
+	}
+	return available
+}
+
+// withStorefrontFallback calls get with storefront and then, while get reports
+// that the resource was not found, with each fallback storefront.
+func (s *CatalogService) withStorefrontFallback(storefront string, get func(storefront string) error) error {
+	err := get(storefront)
+	for _, fallback := range s.storefrontFallback {
+		if err == nil || !isNotFound(err) {
+			break
+		}
+
+		if fallback != storefront {
+			err = get(fallback)
+		}
+	}
+
+	return err
+}
+
+// isNotFound reports whether err is a 404 Not Found API error or an empty
+// response.
+func isNotFound(err error) bool {
+	var apiErr *errors.APIError
+	if stderrors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	return errors.IsEmptyResponse(err)
+}
+
 // GetSong gets a song by ID.
 func (s *CatalogService) GetSong(ctx context.Context, id string) (*models.Song, error) {
-	path := fmt.Sprintf("catalog/%s/songs/%s", s.storefront, id)
+	return s.getSong(ctx, resolveStorefront(ctx, "", s.storefront), id, s.defaultResourceQueryParams())
+}
 
-	var response models.SongsResponse
-	err := s.client.Get(ctx, path, &response)
-	if err != nil {
+// GetSongWithOptions gets a song by ID with the specified options, for example
+// to request extended attributes with Extend.
+func (s *CatalogService) GetSongWithOptions(ctx context.Context, id string, options models.QueryParameters) (*models.Song, error) {
+	if err := s.validateQueryParams("songs", options); err != nil {
 		return nil, err
 	}
 
-	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("song not found: %s", id)
+	return s.getSong(ctx, resolveStorefront(ctx, options.Storefront, s.storefront), id, s.buildResourceQueryParams(options))
+}
+
+// getSong gets a song by ID from the given storefront.
+func (s *CatalogService) getSong(ctx context.Context, storefront, id string, queryParams url.Values) (*models.Song, error) {
+	var song *models.Song
+	err := s.withStorefrontFallback(storefront, func(storefront string) error {
+		path := s.catalogPath(storefront, fmt.Sprintf("songs/%s", url.PathEscape(id)), queryParams)
+
+		var response models.SongsResponse
+		err := s.client.Get(ctx, path, &response)
+		if err != nil {
+			return err
+		}
+
+		if len(response.Data) == 0 {
+			return emptyResponseError("song not found: %s", id)
+		}
+
+		song = &response.Data[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &response.Data[0], nil
+	return song, nil
 }
 
 // GetSongs gets multiple songs by IDs.
 func (s *CatalogService) GetSongs(ctx context.Context, ids []string) ([]models.Song, error) {
-	if len(ids) == 0 {
-		return nil, fmt.Errorf("at least one ID is required")
+	return s.GetSongsWithOptions(ctx, ids, models.QueryParameters{})
+}
+
+// GetSongsWithOptions gets multiple songs by IDs with the specified options,
+// for example Extend with "artistUrl" or Include with "artists".
+func (s *CatalogService) GetSongsWithOptions(ctx context.Context, ids []string, options models.QueryParameters) ([]models.Song, error) {
+	return getCatalogResources[models.Song](ctx, s, "songs", ids, options)
+}
+
+// GetSongArtists gets the artists of a song. This is useful for tracks with
+// several artists, where ArtistName is a single joined string.
+func (s *CatalogService) GetSongArtists(ctx context.Context, songID string) ([]models.Artist, error) {
+	return getRelationship[models.Artist](ctx, s, "songs", songID, "artists", models.QueryParameters{})
+}
+
+// GetSongAlbums gets the albums a song appears on.
+func (s *CatalogService) GetSongAlbums(ctx context.Context, songID string) ([]models.Album, error) {
+	return getRelationship[models.Album](ctx, s, "songs", songID, "albums", models.QueryParameters{})
+}
+
+// GetSongPlaylists gets catalog playlists for an "appears in" section of a
+// song. Apple does not expose the playlists that feature a song, so these are
+// the playlists that feature the song's album, from the album's appears-on
+// view (see GetAlbumRelatedPlaylists); they usually, but not always, include
+// the song itself. Use options.Limit and options.Offset to page through them.
+func (s *CatalogService) GetSongPlaylists(ctx context.Context, songID string, options models.QueryParameters) ([]models.Playlist, error) {
+	song, err := s.getSong(ctx, resolveStorefront(ctx, options.Storefront, s.storefront), songID, s.defaultResourceQueryParams())
+	if err != nil {
+		return nil, err
 	}
 
-	queryParams := url.Values{}
-	queryParams.Set("ids", commaSeparated(ids))
+	albumID := song.AlbumID()
+	if albumID == "" {
+		return nil, emptyResponseError("album not found for song: %s", songID)
+	}
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/songs", s.storefront), queryParams)
+	return s.GetAlbumRelatedPlaylists(ctx, albumID, options)
+}
+
+// GetLyrics gets the lyrics of a song, which requires a user token. The raw
+// TTML and the plain text are populated from Apple's response; use Synced on
+// the result for time-synced lines, which are empty for lyrics that are not
+// time-synced.
+func (s *CatalogService) GetLyrics(ctx context.Context, songID string) (*models.Lyrics, error) {
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), fmt.Sprintf("songs/%s/lyrics", url.PathEscape(songID)), s.defaultResourceQueryParams())
 
-	var response models.SongsResponse
+	var response models.LyricsResponse
 	err := s.client.Get(ctx, path, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	return response.Data, nil
+	if len(response.Data) == 0 {
+		return nil, emptyResponseError("lyrics not found for song: %s", songID)
+	}
+
+	lyrics, err := models.NewLyrics(response.Data[0].ID, response.Data[0].Attributes.TTML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lyrics of song %s: %w", songID, err)
+	}
+
+	return lyrics, nil
 }
 
 // GetAlbum gets an album by ID.
 func (s *CatalogService) GetAlbum(ctx context.Context, id string) (*models.Album, error) {
-	path := fmt.Sprintf("catalog/%s/albums/%s", s.storefront, id)
+	return s.getAlbum(ctx, id, s.defaultResourceQueryParams())
+}
 
-	var response models.AlbumsResponse
-	err := s.client.Get(ctx, path, &response)
+// GetAlbumWithTrackPreviews gets an album with its tracks included, along with
+// the preview URL of each track keyed by track ID. Previews missing from the
+// included tracks are looked up with a single batch song request. IDs of tracks
+// without a preview are returned in missing, in album order.
+func (s *CatalogService) GetAlbumWithTrackPreviews(ctx context.Context, id string) (album *models.Album, previews map[string]string, missing []string, err error) {
+	queryParams := s.defaultResourceQueryParams()
+	queryParams.Set("include", "tracks")
+
+	album, err = s.getAlbum(ctx, id, queryParams)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
-	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("album not found: %s", id)
+	var tracks []models.Song
+	if err := album.Relationships.Tracks.DecodeInto(&tracks); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode tracks of album %s: %w", id, err)
+	}
+
+	previews = make(map[string]string, len(tracks))
+	var lookup []string
+	for i := range tracks {
+		if previewURL := tracks[i].GetPreviewURL(); previewURL != "" {
+			previews[tracks[i].ID] = previewURL
+		} else if tracks[i].Type == models.ResourceTypeSongs.String() {
+			lookup = append(lookup, tracks[i].ID)
+		}
 	}
 
-	return &response.Data[0], nil
+	if len(lookup) > 0 {
+		found, _, err := s.GetSongPreviewURLs(ctx, lookup)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for trackID, previewURL := range found {
+			previews[trackID] = previewURL
+		}
+	}
+
+	for _, track := range tracks {
+		if _, ok := previews[track.ID]; !ok {
+			missing = append(missing, track.ID)
+		}
+	}
+
+	return album, previews, missing, nil
 }
 
-// GetAlbums gets multiple albums by IDs.
-func (s *CatalogService) GetAlbums(ctx context.Context, ids []string) ([]models.Album, error) {
-	if len(ids) == 0 {
-		return nil, fmt.Errorf("at least one ID is required")
+// GetAlbumTrackArtists gets the artists credited on each track of an album,
+// keyed by track ID, by paging through the album's tracks with their artists
+// included. This covers compilations and other albums whose tracks feature
+// artists other than the album artist. IDs of tracks whose artists could not be
+// resolved are returned in unresolved, in album order.
+func (s *CatalogService) GetAlbumTrackArtists(ctx context.Context, albumID string) (artists map[string][]models.Artist, unresolved []string, err error) {
+	queryParams := s.defaultQueryParams()
+	queryParams.Set("include", "artists")
+
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), fmt.Sprintf("albums/%s/tracks", url.PathEscape(albumID)), queryParams)
+
+	artists = make(map[string][]models.Artist)
+	err = forEachPage(ctx, s.client, path, s.pageSizeFor(MaxCatalogPageSize), func(tracks []models.Song) bool {
+		for _, track := range tracks {
+			var trackArtists []models.Artist
+			if err := track.Relationships.Artists.DecodeInto(&trackArtists); err != nil || len(trackArtists) == 0 {
+				unresolved = append(unresolved, track.ID)
+				continue
+			}
+			artists[track.ID] = trackArtists
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	queryParams := url.Values{}
-	queryParams.Set("ids", commaSeparated(ids))
+	return artists, unresolved, nil
+}
+
+// GetAlbumRelatedPlaylists gets catalog playlists that feature the album with
+// the given ID, from the album's appears-on view. Use options.Limit and
+// options.Offset to page through the view.
+func (s *CatalogService) GetAlbumRelatedPlaylists(ctx context.Context, albumID string, options models.QueryParameters) ([]models.Playlist, error) {
+	if err := s.validateQueryParams("albums", options); err != nil {
+		return nil, err
+	}
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/albums", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront),
+		fmt.Sprintf("albums/%s/view/appears-on", url.PathEscape(albumID)), s.buildQueryParams(options))
 
-	var response models.AlbumsResponse
+	var response models.PlaylistsResponse
 	err := s.client.Get(ctx, path, &response)
 	if err != nil {
 		return nil, err
@@ -102,9 +324,83 @@ func (s *CatalogService) GetAlbums(ctx context.Context, ids []string) ([]models.
 	return response.Data, nil
 }
 
+// getAlbum gets an album by ID with the provided query parameters.
+func (s *CatalogService) getAlbum(ctx context.Context, id string, queryParams url.Values) (*models.Album, error) {
+	var album *models.Album
+	err := s.withStorefrontFallback(resolveStorefront(ctx, "", s.storefront), func(storefront string) error {
+		path := s.catalogPath(storefront, fmt.Sprintf("albums/%s", url.PathEscape(id)), queryParams)
+
+		var response models.AlbumsResponse
+		err := s.client.Get(ctx, path, &response)
+		if err != nil {
+			return err
+		}
+
+		if len(response.Data) == 0 {
+			return emptyResponseError("album not found: %s", id)
+		}
+
+		album = &response.Data[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return album, nil
+}
+
+// GetAlbums gets multiple albums by IDs.
+func (s *CatalogService) GetAlbums(ctx context.Context, ids []string) ([]models.Album, error) {
+	return s.GetAlbumsWithOptions(ctx, ids, models.QueryParameters{})
+}
+
+// GetAlbumsWithOptions gets multiple albums by IDs with the specified options,
+// such as Include, Extend, and LanguageTag, which are sent alongside the ids.
+func (s *CatalogService) GetAlbumsWithOptions(ctx context.Context, ids []string, options models.QueryParameters) ([]models.Album, error) {
+	return getCatalogResources[models.Album](ctx, s, "albums", ids, options)
+}
+
 // GetArtist gets an artist by ID.
 func (s *CatalogService) GetArtist(ctx context.Context, id string) (*models.Artist, error) {
-	path := fmt.Sprintf("catalog/%s/artists/%s", s.storefront, id)
+	var artist *models.Artist
+	err := s.withStorefrontFallback(resolveStorefront(ctx, "", s.storefront), func(storefront string) error {
+		path := s.catalogPath(storefront, fmt.Sprintf("artists/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
+
+		var response models.ArtistsResponse
+		err := s.client.Get(ctx, path, &response)
+		if err != nil {
+			return err
+		}
+
+		if len(response.Data) == 0 {
+			return emptyResponseError("artist not found: %s", id)
+		}
+
+		artist = &response.Data[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return artist, nil
+}
+
+// GetArtistFull gets an artist together with its top songs, featured albums,
+// and similar artists in a single request. If options.Views is empty, those
+// three views are requested. Views missing from the response are left empty.
+func (s *CatalogService) GetArtistFull(ctx context.Context, id string, options models.QueryParameters) (*models.ArtistDetail, error) {
+	if len(options.Views) == 0 {
+		options.Views = []string{"top-songs", "featured-albums", "similar-artists"}
+	}
+
+	if err := s.validateQueryParams("artists", options); err != nil {
+		return nil, err
+	}
+
+	queryParams := s.buildResourceQueryParams(options)
+	path := s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront), fmt.Sprintf("artists/%s", url.PathEscape(id)), queryParams)
 
 	var response models.ArtistsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -113,22 +409,55 @@ func (s *CatalogService) GetArtist(ctx context.Context, id string) (*models.Arti
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("artist not found: %s", id)
+		return nil, emptyResponseError("artist not found: %s", id)
 	}
 
-	return &response.Data[0], nil
+	artist := response.Data[0]
+
+	return &models.ArtistDetail{
+		Artist:         artist,
+		TopSongs:       artist.Views.TopSongs.Data,
+		FeaturedAlbums: artist.Views.FeaturedAlbums.Data,
+		SimilarArtists: artist.Views.SimilarArtists.Data,
+	}, nil
 }
 
-// GetArtists gets multiple artists by IDs.
-func (s *CatalogService) GetArtists(ctx context.Context, ids []string) ([]models.Artist, error) {
-	if len(ids) == 0 {
-		return nil, fmt.Errorf("at least one ID is required")
+// GetArtistViewPage gets a page of a single view of an artist, such as
+// "top-songs", without fetching the artist itself. Set options.Limit for the
+// page size and options.Offset to the NextOffset of the previous page's
+// Pagination to load more. Decode the page with DecodeInto into a slice of the
+// view's resource type.
+func (s *CatalogService) GetArtistViewPage(ctx context.Context, artistID, viewName string, options models.QueryParameters) (*models.ViewPage, error) {
+	if viewName == "" {
+		return nil, fmt.Errorf("view name is required")
 	}
 
-	queryParams := url.Values{}
-	queryParams.Set("ids", commaSeparated(ids))
+	if err := s.validateQueryParams("artists", models.QueryParameters{Views: []string{viewName}}); err != nil {
+		return nil, err
+	}
+
+	queryParams := s.buildQueryParams(options)
+	path := s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront), fmt.Sprintf("artists/%s/view/%s", url.PathEscape(artistID), url.PathEscape(viewName)), queryParams)
+
+	var response models.ViewPage
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/artists", s.storefront), queryParams)
+// GetRelatedArtists gets artists similar to the artist with the given ID from
+// the artist's similar-artists view. Use options.Limit and options.Offset to
+// page through the view.
+func (s *CatalogService) GetRelatedArtists(ctx context.Context, artistID string, options models.QueryParameters) ([]models.Artist, error) {
+	if err := s.validateQueryParams("artists", options); err != nil {
+		return nil, err
+	}
+
+	path := s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront),
+		fmt.Sprintf("artists/%s/view/similar-artists", url.PathEscape(artistID)), s.buildQueryParams(options))
 
 	var response models.ArtistsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -139,56 +468,744 @@ func (s *CatalogService) GetArtists(ctx context.Context, ids []string) ([]models
 	return response.Data, nil
 }
 
+// GetArtistAllSongs gets every song of the artist with the given ID, unlike
+// the artist's top-songs view. The artist's albums are paged through in full,
+// then the tracks of each album are fetched, running at most the service's
+// concurrency limit of requests at once. Songs are returned in album order,
+// without duplicates. Options apply to the album and track requests, except for
+// Limit, as every page is requested with the service's page size (see
+// SetPageSize). If the tracks of any album could not be fetched, the songs of
+// the other albums are returned alongside an *errors.BatchError with the error
+// of each failed album, keyed by album ID.
+func (s *CatalogService) GetArtistAllSongs(ctx context.Context, artistID string, options models.QueryParameters) ([]models.Song, error) {
+	storefront := resolveStorefront(ctx, options.Storefront, s.storefront)
+	queryParams := s.buildQueryParams(options)
+
+	var albumIDs []string
+	albumsPath := s.catalogPath(storefront, fmt.Sprintf("artists/%s/albums", url.PathEscape(artistID)), queryParams)
+	err := forEachPage(ctx, s.client, albumsPath, s.pageSizeFor(MaxCatalogPageSize), func(albums []models.Album) bool {
+		for _, album := range albums {
+			albumIDs = append(albumIDs, album.ID)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	tracks := make(map[string][]models.Song, len(albumIDs))
+	errs := forEachConcurrently(ctx, albumIDs, s.concurrencyLimit(), func(ctx context.Context, albumID string) error {
+		var songs []models.Song
+		tracksPath := s.catalogPath(storefront, fmt.Sprintf("albums/%s/tracks", url.PathEscape(albumID)), queryParams)
+		err := forEachPage(ctx, s.client, tracksPath, s.pageSizeFor(MaxCatalogPageSize), func(page []models.Song) bool {
+			songs = append(songs, page...)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		tracks[albumID] = songs
+		return nil
+	})
+
+	var songs []models.Song
+	seen := make(map[string]bool)
+	for _, albumID := range albumIDs {
+		for _, song := range tracks[albumID] {
+			if (song.Type != "" && song.Type != models.ResourceTypeSongs.String()) || seen[song.ID] {
+				continue
+			}
+			seen[song.ID] = true
+			songs = append(songs, song)
+		}
+	}
+
+	if len(errs) > 0 {
+		return songs, &errors.BatchError{Errors: errs}
+	}
+
+	return songs, nil
+}
+
+// GetArtists gets multiple artists by IDs.
+func (s *CatalogService) GetArtists(ctx context.Context, ids []string) ([]models.Artist, error) {
+	return s.GetArtistsWithOptions(ctx, ids, models.QueryParameters{})
+}
+
+// GetArtistsWithOptions gets multiple artists by IDs with the specified options,
+// such as Include, Extend, and LanguageTag, which are sent alongside the ids.
+func (s *CatalogService) GetArtistsWithOptions(ctx context.Context, ids []string, options models.QueryParameters) ([]models.Artist, error) {
+	return getCatalogResources[models.Artist](ctx, s, "artists", ids, options)
+}
+
 // GetPlaylist gets a playlist by ID.
 func (s *CatalogService) GetPlaylist(ctx context.Context, id string) (*models.Playlist, error) {
-	path := fmt.Sprintf("catalog/%s/playlists/%s", s.storefront, id)
+	var playlist *models.Playlist
+	err := s.withStorefrontFallback(resolveStorefront(ctx, "", s.storefront), func(storefront string) error {
+		path := s.catalogPath(storefront, fmt.Sprintf("playlists/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
 
-	var response models.PlaylistsResponse
+		var response models.PlaylistsResponse
+		err := s.client.Get(ctx, path, &response)
+		if err != nil {
+			return err
+		}
+
+		if len(response.Data) == 0 {
+			return emptyResponseError("playlist not found: %s", id)
+		}
+
+		playlist = &response.Data[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return playlist, nil
+}
+
+// GetPlaylists gets multiple playlists by IDs.
+func (s *CatalogService) GetPlaylists(ctx context.Context, ids []string) ([]models.Playlist, error) {
+	return s.GetPlaylistsWithOptions(ctx, ids, models.QueryParameters{})
+}
+
+// GetPlaylistsWithOptions gets multiple playlists by IDs with the specified options,
+// such as Include, Extend, and LanguageTag, which are sent alongside the ids.
+func (s *CatalogService) GetPlaylistsWithOptions(ctx context.Context, ids []string, options models.QueryParameters) ([]models.Playlist, error) {
+	return getCatalogResources[models.Playlist](ctx, s, "playlists", ids, options)
+}
+
+// GetSongPreviewURL gets the preview URL for a song by ID.
+func (s *CatalogService) GetSongPreviewURL(ctx context.Context, id string) (string, error) {
+	return s.GetSongPreviewURLForStorefront(ctx, id, resolveStorefront(ctx, "", s.storefront))
+}
+
+// GetSongPreviewURLForStorefront gets the preview URL for a song by ID from the
+// given storefront, without changing the service's default storefront.
+// Preview availability varies by storefront.
+func (s *CatalogService) GetSongPreviewURLForStorefront(ctx context.Context, id, storefront string) (string, error) {
+	if storefront == "" {
+		return "", fmt.Errorf("storefront is required")
+	}
+
+	song, err := s.getSong(ctx, storefront, id, s.defaultResourceQueryParams())
+	if err != nil {
+		return "", err
+	}
+
+	previewURL := song.GetPreviewURL()
+	if previewURL == "" {
+		return "", fmt.Errorf("no preview available for song: %s", id)
+	}
+
+	return previewURL, nil
+}
+
+// GetSongPreviewURLs gets the preview URLs for multiple songs with a single
+// request, keyed by song ID. IDs of songs that were not found or have no
+// preview are returned in missing, in the order they were given.
+func (s *CatalogService) GetSongPreviewURLs(ctx context.Context, ids []string) (previews map[string]string, missing []string, err error) {
+	songs, err := s.GetSongs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previews = make(map[string]string, len(songs))
+	for _, song := range songs {
+		if previewURL := song.GetPreviewURL(); previewURL != "" {
+			previews[song.ID] = previewURL
+		}
+	}
+
+	for _, id := range ids {
+		if _, ok := previews[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	return previews, missing, nil
+}
+
+// GetByPlayParams resolves play parameters, such as those of a recommendation
+// or a library item, to the full catalog resource. The catalog ID is used when
+// set, otherwise the ID, and the kind selects the getter. The result is a
+// *models.Song, *models.Album, *models.Playlist, *models.MusicVideo, or
+// *models.Station.
+func (s *CatalogService) GetByPlayParams(ctx context.Context, pp models.PlayParameters) (interface{}, error) {
+	id := pp.CatalogID
+	if id == "" {
+		if pp.IsLibrary {
+			return nil, fmt.Errorf("play parameters of library %s %s have no catalog ID", pp.Kind, pp.ID)
+		}
+		id = pp.ID
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("play parameters have no ID")
+	}
+
+	var resourceType models.ResourceType
+	switch pp.Kind {
+	case "song":
+		resourceType = models.ResourceTypeSongs
+	case "album":
+		resourceType = models.ResourceTypeAlbums
+	case "playlist":
+		resourceType = models.ResourceTypePlaylists
+	case "musicVideo":
+		resourceType = models.ResourceTypeMusicVideos
+	case "radioStation", "station":
+		resourceType = models.ResourceTypeStations
+	default:
+		return nil, fmt.Errorf("unsupported play parameters kind: %s", pp.Kind)
+	}
+
+	return s.GetResource(ctx, resourceType, id)
+}
+
+// GetResource gets a catalog resource of the given type by ID. The result is a
+// *models.Song, *models.Album, *models.Artist, *models.Playlist,
+// *models.MusicVideo, or *models.Station.
+func (s *CatalogService) GetResource(ctx context.Context, resourceType models.ResourceType, id string) (interface{}, error) {
+	var resource interface{}
+	var err error
+	switch resourceType {
+	case models.ResourceTypeSongs:
+		resource, err = s.GetSong(ctx, id)
+	case models.ResourceTypeAlbums:
+		resource, err = s.GetAlbum(ctx, id)
+	case models.ResourceTypeArtists:
+		resource, err = s.GetArtist(ctx, id)
+	case models.ResourceTypePlaylists:
+		resource, err = s.GetPlaylist(ctx, id)
+	case models.ResourceTypeMusicVideos:
+		resource, err = getCatalogResource[models.MusicVideo](ctx, s, resourceType.String(), id)
+	case models.ResourceTypeStations:
+		resource, err = getCatalogResource[models.Station](ctx, s, resourceType.String(), id)
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// ResolveGraphBatchSize is the maximum number of IDs ResolveGraph requests in
+// one call to a catalog endpoint.
+const ResolveGraphBatchSize = 100
+
+// ResolveGraph gets the full catalog resources for a mixed set of references,
+// such as those gathered from several relationships. References are grouped by
+// type and fetched with one request per ResolveGraphBatchSize IDs of a type,
+// running at most the service's concurrency limit of requests at once (see
+// SetConcurrency). Duplicate references are fetched once.
+//
+// Resources are returned keyed by "type:id", as typed values decoded by
+// models.DecodeResource. If any reference could not be resolved, an
+// *errors.BatchError is returned alongside the resolved resources, with an
+// error for each unresolved reference keyed the same way. References that were
+// not found match errors.ErrEmptyResponse. References to library resources,
+// such as library-songs, are not fetched and are reported as unresolved.
+func (s *CatalogService) ResolveGraph(ctx context.Context, refs []models.Resource) (map[string]interface{}, error) {
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("at least one reference is required")
+	}
+
+	errs := make(map[string]error)
+	idsByType := make(map[string][]string)
+	seen := make(map[string]bool, len(refs))
+	var types []string
+	for _, ref := range refs {
+		key := graphKey(ref.Type, ref.ID)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if ref.Type == "" || ref.ID == "" {
+			errs[key] = fmt.Errorf("reference must have a type and an ID")
+			continue
+		}
+
+		if t, err := models.ParseResourceType(ref.Type); err != nil || t.IsLibrary() {
+			errs[key] = fmt.Errorf("invalid catalog resource type: %s", ref.Type)
+			continue
+		}
+
+		if _, ok := idsByType[ref.Type]; !ok {
+			types = append(types, ref.Type)
+		}
+		idsByType[ref.Type] = append(idsByType[ref.Type], ref.ID)
+	}
+
+	type batch struct {
+		resourceType string
+		ids          []string
+	}
+	batches := make(map[string]batch)
+	var batchKeys []string
+	for _, resourceType := range types {
+		ids := idsByType[resourceType]
+		for start := 0; start < len(ids); start += ResolveGraphBatchSize {
+			batchKey := fmt.Sprintf("%s[%d]", resourceType, start)
+			batches[batchKey] = batch{resourceType: resourceType, ids: ids[start:min(start+ResolveGraphBatchSize, len(ids))]}
+			batchKeys = append(batchKeys, batchKey)
+		}
+	}
+
+	var mu sync.Mutex
+	resources := make(map[string]interface{}, len(seen))
+	batchErrs := forEachConcurrently(ctx, batchKeys, s.concurrencyLimit(), func(ctx context.Context, batchKey string) error {
+		resourceType := batches[batchKey].resourceType
+		data, err := getCatalogResources[json.RawMessage](ctx, s, resourceType, batches[batchKey].ids, models.QueryParameters{})
+		if err != nil {
+			return err
+		}
+
+		decoded := make(map[string]interface{}, len(data))
+		for _, raw := range data {
+			var ref models.Resource
+			if err := json.Unmarshal(raw, &ref); err != nil {
+				return fmt.Errorf("failed to decode %s: %w", resourceType, err)
+			}
+
+			resource, err := models.DecodeResource(raw)
+			if err != nil {
+				return fmt.Errorf("failed to decode %s %s: %w", ref.Type, ref.ID, err)
+			}
+			decoded[graphKey(ref.Type, ref.ID)] = resource
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for key, resource := range decoded {
+			resources[key] = resource
+		}
+		return nil
+	})
+
+	for _, batchKey := range batchKeys {
+		b := batches[batchKey]
+		for _, id := range b.ids {
+			key := graphKey(b.resourceType, id)
+			if err, ok := batchErrs[batchKey]; ok {
+				errs[key] = err
+			} else if _, ok := resources[key]; !ok {
+				errs[key] = emptyResponseError("%s not found: %s", b.resourceType, id)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return resources, &errors.BatchError{Errors: errs}
+	}
+
+	return resources, nil
+}
+
+// graphKey returns the "type:id" key of a resource in the result of ResolveGraph.
+func graphKey(resourceType, id string) string {
+	return resourceType + ":" + id
+}
+
+// GetRelationship gets a relationship of a catalog resource, such as the
+// albums of an artist, from catalog/{storefront}/{type}/{id}/{relationship},
+// and returns the data of the response undecoded. Options page the
+// relationship with Limit and Offset; use the typed wrappers, such as
+// GetArtistAlbums, for common relationships.
+func (s *CatalogService) GetRelationship(ctx context.Context, resourceType, id, relationship string, options models.QueryParameters) (json.RawMessage, error) {
+	if t, err := models.ParseResourceType(resourceType); err != nil || t.IsLibrary() {
+		return nil, fmt.Errorf("invalid catalog resource type: %s", resourceType)
+	}
+
+	if id == "" || relationship == "" {
+		return nil, fmt.Errorf("resource ID and relationship are required")
+	}
+
+	if err := s.validateQueryParams(resourceType, options); err != nil {
+		return nil, err
+	}
+
+	resource := fmt.Sprintf("%s/%s/%s", url.PathEscape(resourceType), url.PathEscape(id), url.PathEscape(relationship))
+	path := s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront), resource, s.buildQueryParams(options))
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
 	err := s.client.Get(ctx, path, &response)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("playlist not found: %s", id)
+	return response.Data, nil
+}
+
+// GetArtistAlbums gets the albums of an artist.
+func (s *CatalogService) GetArtistAlbums(ctx context.Context, artistID string, options models.QueryParameters) ([]models.Album, error) {
+	return getRelationship[models.Album](ctx, s, "artists", artistID, "albums", options)
+}
+
+// GetArtistMusicVideos gets the music videos of an artist.
+func (s *CatalogService) GetArtistMusicVideos(ctx context.Context, artistID string, options models.QueryParameters) ([]models.MusicVideo, error) {
+	return getRelationship[models.MusicVideo](ctx, s, "artists", artistID, "music-videos", options)
+}
+
+// GetAlbumArtists gets the artists of an album.
+func (s *CatalogService) GetAlbumArtists(ctx context.Context, albumID string, options models.QueryParameters) ([]models.Artist, error) {
+	return getRelationship[models.Artist](ctx, s, "albums", albumID, "artists", options)
+}
+
+// GetPlaylistCurator gets the curator of a playlist.
+func (s *CatalogService) GetPlaylistCurator(ctx context.Context, playlistID string) (*models.Curator, error) {
+	curators, err := getRelationship[models.Curator](ctx, s, "playlists", playlistID, "curator", models.QueryParameters{})
+	if err != nil {
+		return nil, err
 	}
 
-	return &response.Data[0], nil
+	if len(curators) == 0 {
+		return nil, emptyResponseError("curator not found for playlist: %s", playlistID)
+	}
+
+	return &curators[0], nil
 }
 
-// GetPlaylists gets multiple playlists by IDs.
-func (s *CatalogService) GetPlaylists(ctx context.Context, ids []string) ([]models.Playlist, error) {
+// getRelationship gets a relationship of a catalog resource decoded as a
+// slice of T.
+func getRelationship[T any](ctx context.Context, s *CatalogService, resourceType, id, relationship string, options models.QueryParameters) ([]T, error) {
+	data, err := s.GetRelationship(ctx, resourceType, id, relationship, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var resources []T
+	if err := json.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("failed to decode %s of %s %s: %w", relationship, resourceType, id, err)
+	}
+
+	return resources, nil
+}
+
+// GetEquivalents gets the ID of the equivalent of a catalog resource, such as
+// a song, in each of the given storefronts, using filter[equivalents]. The
+// storefronts are queried concurrently, running at most the service's
+// concurrency limit of requests at once (see SetConcurrency). IDs are returned
+// by storefront; if any storefront has no equivalent or failed, an
+// *errors.BatchError is returned alongside them with the error of each such
+// storefront. Storefronts without an equivalent match errors.ErrEmptyResponse.
+func (s *CatalogService) GetEquivalents(ctx context.Context, resourceType, id string, storefronts []string) (map[string]string, error) {
+	if t, err := models.ParseResourceType(resourceType); err != nil || t.IsLibrary() {
+		return nil, fmt.Errorf("invalid catalog resource type: %s", resourceType)
+	}
+
+	if id == "" {
+		return nil, fmt.Errorf("resource ID is required")
+	}
+
+	if len(storefronts) == 0 {
+		return nil, fmt.Errorf("at least one storefront is required")
+	}
+
+	var mu sync.Mutex
+	equivalents := make(map[string]string, len(storefronts))
+	errs := forEachConcurrently(ctx, storefronts, s.concurrencyLimit(), func(ctx context.Context, storefront string) error {
+		queryParams := s.defaultResourceQueryParams()
+		queryParams.Set("filter[equivalents]", id)
+
+		var response page[models.Resource]
+		err := s.client.Get(ctx, s.catalogPath(storefront, url.PathEscape(resourceType), queryParams), &response)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+
+		if len(response.Data) == 0 {
+			return emptyResponseError("no equivalent of %s %s in storefront: %s", resourceType, id, storefront)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		equivalents[storefront] = response.Data[0].ID
+		return nil
+	})
+
+	if len(errs) > 0 {
+		return equivalents, &errors.BatchError{Errors: errs}
+	}
+
+	return equivalents, nil
+}
+
+// MaxArtworkBytes is the largest artwork image DownloadArtwork accepts.
+const MaxArtworkBytes = 20 << 20
+
+// DownloadArtwork downloads an artwork image at the given size, templated into
+// the artwork URL as by Artwork.URLForSize, and returns the image and its
+// content type, such as "image/jpeg". Images larger than MaxArtworkBytes are
+// rejected.
+func (s *CatalogService) DownloadArtwork(ctx context.Context, artwork models.Artwork, width, height int) ([]byte, string, error) {
+	if artwork.URL == "" {
+		return nil, "", fmt.Errorf("artwork has no URL")
+	}
+
+	return s.client.Download(ctx, artwork.URLForSize(width, height), MaxArtworkBytes)
+}
+
+// getCatalogResource gets a single catalog resource of type T by ID.
+func getCatalogResource[T any](ctx context.Context, s *CatalogService, resource, id string) (*T, error) {
+	var result *T
+	err := s.withStorefrontFallback(resolveStorefront(ctx, "", s.storefront), func(storefront string) error {
+		path := s.catalogPath(storefront, fmt.Sprintf("%s/%s", resource, url.PathEscape(id)), s.defaultResourceQueryParams())
+
+		var response struct {
+			Data []T `json:"data"`
+		}
+		err := s.client.Get(ctx, path, &response)
+		if err != nil {
+			return err
+		}
+
+		if len(response.Data) == 0 {
+			return emptyResponseError("%s not found: %s", resource, id)
+		}
+
+		result = &response.Data[0]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getCatalogResources gets multiple catalog resources of type T by IDs, with
+// the ids parameter merged into the query parameters built from options.
+func getCatalogResources[T any](ctx context.Context, s *CatalogService, resource string, ids []string, options models.QueryParameters) ([]T, error) {
 	if len(ids) == 0 {
 		return nil, fmt.Errorf("at least one ID is required")
 	}
 
-	queryParams := url.Values{}
+	if err := s.validateQueryParams(resource, options); err != nil {
+		return nil, err
+	}
+
+	queryParams := s.buildResourceQueryParams(options)
 	queryParams.Set("ids", commaSeparated(ids))
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/playlists", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront), resource, queryParams)
 
-	var response models.PlaylistsResponse
+	var response struct {
+		Data []T `json:"data"`
+	}
 	err := s.client.Get(ctx, path, &response)
 	if err != nil {
 		return nil, err
 	}
 
+	if s.skipUnavailable && len(s.withDefaults(options).Fields[resource]) == 0 {
+		return availableOnly(response.Data), nil
+	}
+
 	return response.Data, nil
 }
 
-// GetSongPreviewURL gets the preview URL for a song by ID.
-func (s *CatalogService) GetSongPreviewURL(ctx context.Context, id string) (string, error) {
-	song, err := s.GetSong(ctx, id)
+// genreList is a cached genre list. Its mutex is held while the list is
+// fetched, so that concurrent calls for the same list fetch it only once
+// without blocking calls for other lists.
+type genreList struct {
+	mu      sync.Mutex
+	fetched bool
+	genres  []models.Genre
+}
+
+// GetGenres gets all genres of the storefront, in the service's language. The
+// list is fetched once per storefront and language and cached for the
+// lifetime of the service; a failed fetch is not cached. The returned slice is
+// a copy that the caller may modify.
+func (s *CatalogService) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "genres", s.defaultQueryParams())
+
+	s.genresMu.Lock()
+	if s.genres == nil {
+		s.genres = make(map[string]*genreList)
+	}
+	list, ok := s.genres[path]
+	if !ok {
+		list = &genreList{}
+		s.genres[path] = list
+	}
+	s.genresMu.Unlock()
+
+	list.mu.Lock()
+	defer list.mu.Unlock()
+
+	if !list.fetched {
+		var genres []models.Genre
+		err := forEachPage(ctx, s.client, path, s.pageSizeFor(MaxCatalogPageSize), func(page []models.Genre) bool {
+			genres = append(genres, page...)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		list.genres = genres
+		list.fetched = true
+	}
+
+	return append([]models.Genre(nil), list.genres...), nil
+}
+
+// ResolveGenres matches genre display names, such as those in a song's
+// GenreNames, to the storefront's genres, ignoring case. The result is keyed
+// by the given names; names without a matching genre are omitted.
+func (s *CatalogService) ResolveGenres(ctx context.Context, names []string) (map[string]models.Genre, error) {
+	genres, err := s.GetGenres(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	previewURL := song.GetPreviewURL()
-	if previewURL == "" {
-		return "", fmt.Errorf("no preview available for song: %s", id)
+	byName := make(map[string]models.Genre, len(genres))
+	for _, genre := range genres {
+		byName[strings.ToLower(genre.Attributes.Name)] = genre
 	}
 
-	return previewURL, nil
+	resolved := make(map[string]models.Genre, len(names))
+	for _, name := range names {
+		if genre, ok := byName[strings.ToLower(strings.TrimSpace(name))]; ok {
+			resolved[name] = genre
+		}
+	}
+
+	return resolved, nil
+}
+
+// GetCharts gets the charts for the given resource types, such as songs, albums, and playlists.
+func (s *CatalogService) GetCharts(ctx context.Context, types []string, options *models.ChartOptions) (*models.ChartResponse, error) {
+	return s.getCharts(ctx, resolveStorefront(ctx, "", s.storefront), types, options)
+}
+
+// GetGenreCharts gets the charts for the given resource types scoped to a
+// genre, such as the top pop songs, with up to limit resources per chart. A
+// limit of zero or less uses the API default. The genre ID is the numeric ID of
+// a genre from GetGenres. Charts of genres with too little activity may contain
+// no resources, which is not an error; check the result with IsEmpty.
+func (s *CatalogService) GetGenreCharts(ctx context.Context, genreID string, types []string, limit int) (*models.ChartResponse, error) {
+	if !isNumericID(genreID) {
+		return nil, fmt.Errorf("invalid genre ID: %q", genreID)
+	}
+
+	return s.GetCharts(ctx, types, &models.ChartOptions{Genre: genreID, Limit: limit})
+}
+
+// isNumericID reports whether id is a non-empty string of ASCII digits, the
+// form of catalog IDs such as genre IDs.
+func isNumericID(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// GetChartsNext gets the next page of a chart using the Next href of a chart group.
+func (s *CatalogService) GetChartsNext(ctx context.Context, next string) (*models.ChartResponse, error) {
+	if next == "" {
+		return nil, fmt.Errorf("next href is required")
+	}
+
+	var response struct {
+		Results models.ChartResponse `json:"results"`
+	}
+
+	err := s.client.Get(ctx, s.client.RelativePath(next), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+// GetMultipleStorefrontCharts gets the charts for the given resource types in
+// each of the storefronts concurrently, running at most the service's
+// concurrency limit of requests at once (see SetConcurrency). It returns the
+// charts by storefront and, if any storefront failed, an *errors.BatchError
+// with the error of each failed storefront.
+func (s *CatalogService) GetMultipleStorefrontCharts(ctx context.Context, storefronts []string, types []string, options *models.ChartOptions) (map[string]*models.ChartResponse, error) {
+	if len(storefronts) == 0 {
+		return nil, fmt.Errorf("at least one storefront is required")
+	}
+
+	var mu sync.Mutex
+	charts := make(map[string]*models.ChartResponse, len(storefronts))
+	errs := forEachConcurrently(ctx, storefronts, s.concurrencyLimit(), func(ctx context.Context, storefront string) error {
+		response, err := s.getCharts(ctx, storefront, types, options)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		charts[storefront] = response
+		return nil
+	})
+
+	if len(errs) > 0 {
+		return charts, &errors.BatchError{Errors: errs}
+	}
+
+	return charts, nil
+}
+
+// getCharts gets the charts for the given resource types from the given storefront.
+func (s *CatalogService) getCharts(ctx context.Context, storefront string, types []string, options *models.ChartOptions) (*models.ChartResponse, error) {
+	if len(types) == 0 {
+		return nil, fmt.Errorf("at least one chart type is required")
+	}
+
+	queryParams := s.defaultQueryParams()
+	s.setTypes(types, queryParams)
+
+	if options != nil {
+		if options.Chart != "" {
+			queryParams.Set("chart", options.Chart)
+		}
+
+		if options.Genre != "" {
+			queryParams.Set("genre", options.Genre)
+		}
+
+		s.setLimit(options.Limit, queryParams)
+		s.setOffset(options.Offset, queryParams)
+	}
+
+	path := s.catalogPath(storefront, "charts", queryParams)
+
+	var response struct {
+		Results models.ChartResponse `json:"results"`
+	}
+
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
 }
 
 // joinWithDelimiter joins string slices with the specified delimiter.
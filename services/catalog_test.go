@@ -0,0 +1,394 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/errors"
+	"github.com/marcusziade/musickitkat/mockapi"
+	"github.com/marcusziade/musickitkat/models"
+)
+
+func TestGetArtistAlbums(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/artists/3/albums?limit=5": {Body: `{"data":[` +
+			`{"id":"2","type":"albums","attributes":{"name":"Mock Album"}},` +
+			`{"id":"5","type":"albums","attributes":{"name":"Another Album"}}]}`},
+	})
+
+	albums, err := NewCatalogService(c).GetArtistAlbums(context.Background(), "3", models.QueryParameters{Limit: 5})
+	if err != nil {
+		t.Fatalf("GetArtistAlbums() error = %v", err)
+	}
+
+	if len(albums) != 2 || albums[0].ID != "2" || albums[1].Attributes.Name != "Another Album" {
+		t.Errorf("GetArtistAlbums() = %+v, want albums 2 and 5", albums)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].URL.Path != "/v1/catalog/us/artists/3/albums" {
+		t.Errorf("requests = %v, want one request for /v1/catalog/us/artists/3/albums", requests)
+	}
+}
+
+func TestGetPlaylistCurator(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/playlists/pl.1/curator": {Body: `{"data":[{"id":"976439548","type":"apple-curators","attributes":{"name":"Apple Music Pop"}}]}`},
+		"GET catalog/us/playlists/pl.2/curator": {Body: `{"data":[]}`},
+	})
+	catalog := NewCatalogService(c)
+
+	curator, err := catalog.GetPlaylistCurator(context.Background(), "pl.1")
+	if err != nil {
+		t.Fatalf("GetPlaylistCurator() error = %v", err)
+	}
+
+	if curator.ID != "976439548" || curator.Attributes.Name != "Apple Music Pop" {
+		t.Errorf("GetPlaylistCurator() = %+v, want Apple Music Pop", curator)
+	}
+
+	if path := server.Requests()[0].URL.Path; path != "/v1/catalog/us/playlists/pl.1/curator" {
+		t.Errorf("path = %s, want /v1/catalog/us/playlists/pl.1/curator", path)
+	}
+
+	if _, err := catalog.GetPlaylistCurator(context.Background(), "pl.2"); !stderrors.Is(err, errors.ErrEmptyResponse) {
+		t.Errorf("GetPlaylistCurator() without a curator error = %v, want ErrEmptyResponse", err)
+	}
+}
+
+func TestGetRelationshipRejectsLibraryTypes(t *testing.T) {
+	_, c := newMockClient(t, nil)
+
+	if _, err := NewCatalogService(c).GetRelationship(context.Background(), "library-songs", "i.1", "albums", models.QueryParameters{}); err == nil {
+		t.Error("GetRelationship() with a library type returned no error")
+	}
+}
+
+func TestStorefrontFallbackOnNotFound(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+
+	catalog := NewCatalogService(c)
+	catalog.SetStorefront("gb")
+	catalog.SetStorefrontFallback("us")
+
+	song, err := catalog.GetSong(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("GetSong() error = %v", err)
+	}
+
+	if song.ID != "1" || song.Storefront() != "us" {
+		t.Errorf("GetSong() = song %s from storefront %q, want song 1 from us", song.ID, song.Storefront())
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 || requests[0].URL.Path != "/v1/catalog/gb/songs/1" || requests[1].URL.Path != "/v1/catalog/us/songs/1" {
+		t.Errorf("requests = %v, want gb then us", requests)
+	}
+}
+
+func TestStorefrontFallbackSkipsOtherErrors(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+	server.Handle("GET catalog/gb/songs/1", http.StatusInternalServerError, `{"errors":[{"status":"500","title":"Internal Server Error"}]}`)
+
+	catalog := NewCatalogService(c)
+	catalog.SetStorefront("gb")
+	catalog.SetStorefrontFallback("us")
+
+	_, err := catalog.GetSong(context.Background(), "1")
+
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("GetSong() error = %v, want the 500 API error", err)
+	}
+
+	if requests := server.Requests(); len(requests) != 1 {
+		t.Errorf("got %d requests, want 1 without a fallback", len(requests))
+	}
+}
+
+// songsWithPlaceholderJSON is a songs response with an available song and the
+// placeholder of an unavailable one.
+const songsWithPlaceholderJSON = `{"data":[` +
+	`{"id":"1","type":"songs","attributes":{"name":"Mock Song","playParams":{"id":"1","kind":"song"}}},` +
+	`{"id":"9","type":"songs","attributes":{}}]}`
+
+func TestSkipUnavailableDropsPlaceholders(t *testing.T) {
+	_, c := newMockClient(t, mockapi.Fixtures{"GET catalog/us/songs?ids=1%2C9": {Body: songsWithPlaceholderJSON}})
+	catalog := NewCatalogService(c)
+
+	songs, err := catalog.GetSongs(context.Background(), []string{"1", "9"})
+	if err != nil {
+		t.Fatalf("GetSongs() error = %v", err)
+	}
+	if len(songs) != 2 {
+		t.Errorf("GetSongs() without SetSkipUnavailable returned %d songs, want 2", len(songs))
+	}
+
+	catalog.SetSkipUnavailable(true)
+	songs, err = catalog.GetSongs(context.Background(), []string{"1", "9"})
+	if err != nil {
+		t.Fatalf("GetSongs() error = %v", err)
+	}
+	if len(songs) != 1 || songs[0].ID != "1" {
+		t.Errorf("GetSongs() with SetSkipUnavailable = %+v, want only song 1", songs)
+	}
+}
+
+func TestSkipUnavailableKeepsSparseFields(t *testing.T) {
+	_, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/songs?fields%5Bsongs%5D=name&ids=1%2C2": {Body: `{"data":[` +
+			`{"id":"1","type":"songs","attributes":{"name":"Mock Song"}},` +
+			`{"id":"2","type":"songs","attributes":{"name":"Other Song"}}]}`},
+	})
+
+	catalog := NewCatalogService(c)
+	catalog.SetSkipUnavailable(true)
+
+	songs, err := catalog.GetSongsWithOptions(context.Background(), []string{"1", "2"}, models.QueryParameters{
+		Fields: map[string][]string{"songs": {"name"}},
+	})
+	if err != nil {
+		t.Fatalf("GetSongsWithOptions() error = %v", err)
+	}
+
+	if len(songs) != 2 {
+		t.Errorf("GetSongsWithOptions() with sparse fields returned %d songs, want 2", len(songs))
+	}
+}
+
+func TestSetLanguageAppliesToCatalogRequests(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"catalog/jp/songs/1":        {Body: `{"data":[{"id":"1","type":"songs","attributes":{"name":"Mock Song"}}]}`},
+		"catalog/jp/playlists/pl.1": {Body: `{"data":[{"id":"pl.1","type":"playlists","attributes":{"name":"Mock Playlist"}}]}`},
+		"catalog/jp/stations/ra.1":  {Body: `{"data":[{"id":"ra.1","type":"stations"}]}`},
+		"catalog/jp/search":         {Body: `{"results":{}}`},
+		"catalog/jp/search/hints":   {Body: `{"results":{"terms":[]}}`},
+	})
+
+	catalog := NewCatalogService(c)
+	catalog.SetStorefront("jp")
+	catalog.SetLanguage("en-US")
+
+	playlists := NewPlaylistService(c)
+	playlists.SetStorefront("jp")
+	playlists.SetLanguage("en-US")
+
+	radio := NewRadioService(c)
+	radio.SetStorefront("jp")
+	radio.SetLanguage("en-US")
+
+	search := NewSearchService(c)
+	search.SetStorefront("jp")
+	search.SetLanguage("en-US")
+
+	ctx := context.Background()
+	calls := map[string]func() error{
+		"GetSong": func() error {
+			_, err := catalog.GetSong(ctx, "1")
+			return err
+		},
+		"GetCatalogPlaylist": func() error {
+			_, err := playlists.GetCatalogPlaylist(ctx, "pl.1")
+			return err
+		},
+		"GetStation": func() error {
+			_, err := radio.GetStation(ctx, "ra.1")
+			return err
+		},
+		"Search": func() error {
+			_, err := search.Search(ctx, "mock", []string{"songs"}, nil)
+			return err
+		},
+		"SearchHints": func() error {
+			_, err := search.SearchHints(ctx, "mock")
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		if err := call(); err != nil {
+			t.Errorf("%s() error = %v", name, err)
+		}
+	}
+
+	requests := server.Requests()
+	if len(requests) != len(calls) {
+		t.Fatalf("got %d requests, want %d", len(requests), len(calls))
+	}
+	for _, request := range requests {
+		if !strings.HasPrefix(request.URL.Path, "/v1/catalog/jp/") {
+			t.Errorf("request %s does not use the jp storefront", request.URL)
+		}
+		if l := request.URL.Query().Get("l"); l != "en-US" {
+			t.Errorf("request %s has l = %q, want %q", request.URL, l, "en-US")
+		}
+	}
+
+	if _, err := search.Search(ctx, "mock", []string{"songs"}, &models.SearchOptions{LanguageTag: "ja"}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	requests = server.Requests()
+	if l := requests[len(requests)-1].URL.Query().Get("l"); l != "ja" {
+		t.Errorf("l with a per-call language tag = %q, want %q", l, "ja")
+	}
+}
+
+func TestGetCharts(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{"GET catalog/us/charts": {Body: `{"results":{` +
+		`"songs":[{"chart":"most-played","name":"Top Songs","orderId":"most-played:songs",` +
+		`"href":"/v1/catalog/us/charts?chart=most-played&types=songs",` +
+		`"next":"/v1/catalog/us/charts?chart=most-played&offset=1&types=songs",` +
+		`"data":[{"id":"1","type":"songs","attributes":{"name":"Mock Song"}}]}],` +
+		`"albums":[{"chart":"most-played","name":"Top Albums","orderId":"most-played:albums",` +
+		`"href":"/v1/catalog/us/charts?chart=most-played&types=albums",` +
+		`"data":[{"id":"2","type":"albums","attributes":{"name":"Mock Album"}}]}]}}`}})
+	service := NewCatalogService(c)
+
+	charts, err := service.GetCharts(context.Background(), []string{"songs", "albums"}, &models.ChartOptions{Chart: "most-played"})
+	if err != nil {
+		t.Fatalf("GetCharts() error = %v", err)
+	}
+
+	if len(charts.Songs) != 1 || len(charts.Albums) != 1 || len(charts.Playlists) != 0 {
+		t.Fatalf("GetCharts() = %d song, %d album and %d playlist charts, want 1, 1 and 0",
+			len(charts.Songs), len(charts.Albums), len(charts.Playlists))
+	}
+
+	songs := charts.Songs[0]
+	if songs.Chart != "most-played" || songs.Name != "Top Songs" || songs.OrderID != "most-played:songs" {
+		t.Errorf("song chart = %q, %q, %q, want most-played, Top Songs, most-played:songs", songs.Chart, songs.Name, songs.OrderID)
+	}
+	if len(songs.Data) != 1 || songs.Data[0].Attributes.Name != "Mock Song" {
+		t.Errorf("song chart data = %+v, want Mock Song", songs.Data)
+	}
+	if !songs.HasNext() || charts.Albums[0].HasNext() {
+		t.Errorf("HasNext() = %v, %v, want a next page of songs only", songs.HasNext(), charts.Albums[0].HasNext())
+	}
+	if albums := charts.Albums[0].Data; len(albums) != 1 || albums[0].Attributes.Name != "Mock Album" {
+		t.Errorf("album chart data = %+v, want Mock Album", albums)
+	}
+	if charts.IsEmpty() {
+		t.Error("IsEmpty() = true, want false")
+	}
+
+	server.Handle("GET catalog/us/charts?chart=most-played&offset=1&types=songs", http.StatusOK,
+		`{"results":{"songs":[{"chart":"most-played","name":"Top Songs","orderId":"most-played:songs","data":[]}]}}`)
+
+	next, err := service.GetChartsNext(context.Background(), songs.Next)
+	if err != nil {
+		t.Fatalf("GetChartsNext() error = %v", err)
+	}
+	if len(next.Songs) != 1 || next.Songs[0].HasNext() || !next.IsEmpty() {
+		t.Errorf("GetChartsNext() = %+v, want the last, empty page of the song chart", next.Songs)
+	}
+}
+
+func TestGetGenresCachesByStorefrontAndLanguage(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/genres":                   {Body: `{"data":[{"id":"14","type":"genres","attributes":{"name":"Pop"}}]}`},
+		"GET catalog/us/genres?l=es-MX&limit=100": {Body: `{"data":[{"id":"14","type":"genres","attributes":{"name":"Pop en español"}}]}`},
+		"GET catalog/jp/genres":                   {Body: `{"data":[{"id":"27","type":"genres","attributes":{"name":"J-Pop"}}]}`},
+	})
+	service := NewCatalogService(c)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.GetGenres(context.Background()); err != nil {
+				t.Errorf("GetGenres() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	genres, err := service.GetGenres(context.Background())
+	if err != nil {
+		t.Fatalf("GetGenres() error = %v", err)
+	}
+	genres[0].Attributes.Name = "Changed"
+
+	resolved, err := service.ResolveGenres(context.Background(), []string{"pop"})
+	if err != nil {
+		t.Fatalf("ResolveGenres() error = %v", err)
+	}
+	if resolved["pop"].ID != "14" {
+		t.Errorf("ResolveGenres() = %v after modifying a returned list, want Pop", resolved)
+	}
+
+	if _, err := service.GetGenres(WithStorefront(context.Background(), "jp")); err != nil {
+		t.Fatalf("GetGenres() in jp error = %v", err)
+	}
+
+	service.SetLanguage("es-MX")
+	resolved, err = service.ResolveGenres(context.Background(), []string{"Pop en español", "Pop"})
+	if err != nil {
+		t.Fatalf("ResolveGenres() in es-MX error = %v", err)
+	}
+	if _, ok := resolved["Pop en español"]; !ok || len(resolved) != 1 {
+		t.Errorf("ResolveGenres() after SetLanguage = %v, want only the Spanish name", resolved)
+	}
+
+	if requests := server.Requests(); len(requests) != 3 {
+		t.Errorf("got %d requests, want one per storefront and language", len(requests))
+	}
+}
+
+func TestResolveGraphRejectsLibraryRefs(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+
+	resources, err := NewCatalogService(c).ResolveGraph(context.Background(), []models.Resource{
+		{ID: "1", Type: "songs"},
+		{ID: "i.1", Type: "library-songs"},
+	})
+
+	var batchErr *errors.BatchError
+	if !stderrors.As(err, &batchErr) {
+		t.Fatalf("ResolveGraph() error = %v, want a *errors.BatchError", err)
+	}
+	if refErr, ok := batchErr.Errors["library-songs:i.1"]; !ok || !strings.Contains(refErr.Error(), "invalid catalog resource type") {
+		t.Errorf("errors = %v, want library-songs:i.1 rejected as a library type", batchErr.Errors)
+	}
+	if len(batchErr.Errors) != 1 {
+		t.Errorf("errors = %v, want only the library reference", batchErr.Errors)
+	}
+
+	if song, ok := resources["songs:1"].(*models.Song); !ok || song.Attributes.Name != "Mock Song" {
+		t.Errorf("resources = %v, want song 1 resolved", resources)
+	}
+
+	for _, request := range server.Requests() {
+		if strings.Contains(request.URL.Path, "library") {
+			t.Errorf("ResolveGraph() requested %s", request.URL)
+		}
+	}
+}
+
+func TestGetArtistAllSongsReturnsPartialResults(t *testing.T) {
+	_, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/artists/3/albums": {Body: `{"data":[{"id":"2","type":"albums"},{"id":"7","type":"albums"}]}`},
+		"GET catalog/us/albums/2/tracks": {Body: `{"data":[{"id":"1","type":"songs","attributes":{"name":"Mock Song"}},` +
+			`{"id":"5","type":"music-videos","attributes":{"name":"Mock Video"}}]}`},
+		"GET catalog/us/albums/7/tracks": {Status: http.StatusInternalServerError,
+			Body: `{"errors":[{"status":"500","title":"Internal Server Error"}]}`},
+	})
+
+	songs, err := NewCatalogService(c).GetArtistAllSongs(context.Background(), "3", models.QueryParameters{})
+
+	var batchErr *errors.BatchError
+	if !stderrors.As(err, &batchErr) {
+		t.Fatalf("GetArtistAllSongs() error = %v, want a *errors.BatchError", err)
+	}
+	if _, ok := batchErr.Errors["7"]; !ok || len(batchErr.Errors) != 1 {
+		t.Errorf("errors = %v, want only album 7", batchErr.Errors)
+	}
+
+	if len(songs) != 1 || songs[0].ID != "1" {
+		t.Errorf("GetArtistAllSongs() = %+v, want song 1 of album 2", songs)
+	}
+}
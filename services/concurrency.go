@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the default number of requests that helpers which fan
+// out over many storefronts or IDs run at once.
+const DefaultConcurrency = 4
+
+// SetConcurrency sets the number of requests that fan-out helpers run at once.
+// Values less than 1 restore DefaultConcurrency.
+func (s *BaseService) SetConcurrency(n int) {
+	s.concurrency = n
+}
+
+// concurrencyLimit returns the number of requests fan-out helpers run at once.
+func (s *BaseService) concurrencyLimit() int {
+	if s.concurrency < 1 {
+		return DefaultConcurrency
+	}
+	return s.concurrency
+}
+
+// forEachConcurrently calls fn for each key, running at most limit calls at
+// once. Keys that have not started when ctx is done are not called and report
+// the context's error. It returns the errors of the failed calls by key.
+func forEachConcurrently(ctx context.Context, keys []string, limit int, fn func(ctx context.Context, key string) error) map[string]error {
+	var mu sync.Mutex
+	errs := make(map[string]error)
+	setErr := func(key string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs[key] = err
+	}
+
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			setErr(key, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fn(ctx, key); err != nil {
+				setErr(key, err)
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	return errs
+}
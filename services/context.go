@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+
+	"github.com/marcusziade/musickitkat/client"
+)
+
+// contextKey is the type of context keys defined by this package.
+type contextKey int
+
+const (
+	// storefrontKey is the context key for a per-call storefront override.
+	storefrontKey contextKey = iota
+)
+
+// WithStorefront returns a copy of ctx that makes service calls using it target
+// the given storefront instead of the service's default, without mutating the
+// service. This is safe to use concurrently from many goroutines.
+//
+// An explicit storefront passed to a call, such as options.Storefront, takes
+// precedence over the context value.
+func WithStorefront(ctx context.Context, storefront string) context.Context {
+	return context.WithValue(ctx, storefrontKey, storefront)
+}
+
+// WithLogUserID returns a copy of ctx carrying a logical user ID that is
+// included in the client's log lines for calls made with it, to attribute them
+// to an end user. The ID is never sent to the Apple Music API.
+func WithLogUserID(ctx context.Context, userID string) context.Context {
+	return client.WithLogUserID(ctx, userID)
+}
+
+// WithUserToken returns a copy of ctx carrying a music user token that is sent
+// with service calls made with it, so that a single client can serve different
+// users concurrently without calling SetUserToken. The context token takes
+// precedence over the token set on the client; calls made without one use the
+// client's token.
+func WithUserToken(ctx context.Context, token string) context.Context {
+	return client.WithUserToken(ctx, token)
+}
+
+// WithHeaders returns a copy of ctx carrying headers that are sent with service
+// calls made with it only, such as a one-off feature flag, without affecting
+// other calls on the shared client. They are applied after the client's default
+// headers and headers set with WithHeader, replacing ones with the same name,
+// but never replace the Authorization or user token headers.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return client.WithHeaders(ctx, headers)
+}
+
+// resolveStorefront returns the storefront for a call: an explicit storefront
+// wins, then one attached to ctx with WithStorefront, then the service default.
+func resolveStorefront(ctx context.Context, explicit, fallback string) string {
+	if explicit != "" {
+		return explicit
+	}
+
+	if storefront, ok := ctx.Value(storefrontKey).(string); ok && storefront != "" {
+		return storefront
+	}
+
+	return fallback
+}
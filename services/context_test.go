@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/mockapi"
+	"github.com/marcusziade/musickitkat/models"
+)
+
+func TestWithStorefrontConcurrently(t *testing.T) {
+	storefronts := []string{"us", "jp", "gb", "de"}
+
+	fixtures := mockapi.Fixtures{}
+	for _, storefront := range storefronts {
+		fixtures["GET catalog/"+storefront+"/songs/1"] = mockapi.Response{
+			Body: fmt.Sprintf(`{"data":[{"id":"1","type":"songs","attributes":{"name":%q}}]}`, storefront),
+		}
+	}
+	_, c := newMockClient(t, fixtures)
+	service := NewCatalogService(c)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func(storefront string) {
+			defer wg.Done()
+
+			song, err := service.GetSong(WithStorefront(context.Background(), storefront), "1")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if song.Attributes.Name != storefront {
+				errs <- fmt.Errorf("GetSong() with storefront %s fetched from %s", storefront, song.Attributes.Name)
+			}
+		}(storefronts[i%len(storefronts)])
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if got := service.GetStorefront(); got != "us" {
+		t.Errorf("GetStorefront() = %q after context overrides, want %q", got, "us")
+	}
+}
+
+func TestWithStorefrontLosesToExplicitStorefront(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+
+	ctx := WithStorefront(context.Background(), "jp")
+	_, err := NewSearchService(c).Search(ctx, "mock", []string{"songs"}, &models.SearchOptions{Storefront: "us"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if path := server.Requests()[0].URL.Path; path != "/v1/catalog/us/search" {
+		t.Errorf("path = %q, want the explicit storefront", path)
+	}
+}
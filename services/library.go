@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/errors"
 	"github.com/marcusziade/musickitkat/models"
 )
 
@@ -23,7 +25,7 @@ func NewLibraryService(client *client.Client) *LibraryService {
 
 // GetLibrarySongs gets songs from the user's library.
 func (s *LibraryService) GetLibrarySongs(ctx context.Context, limit, offset int) ([]models.Song, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 	s.setOffset(offset, queryParams)
 
@@ -38,9 +40,24 @@ func (s *LibraryService) GetLibrarySongs(ctx context.Context, limit, offset int)
 	return response.Data, nil
 }
 
+// GetLibrarySongsList gets songs from the user's library like GetLibrarySongs,
+// along with the pagination information, meta, and raw JSON of the response.
+func (s *LibraryService) GetLibrarySongsList(ctx context.Context, limit, offset int) (*models.ListResult[models.Song], error) {
+	queryParams := s.defaultQueryParams()
+	s.setLimit(limit, queryParams)
+	s.setOffset(offset, queryParams)
+
+	return getList[models.Song](ctx, s.client, s.buildPath("me/library/songs", queryParams))
+}
+
+// GetLibrarySongsByPage gets a zero-based page of songs from the user's library.
+func (s *LibraryService) GetLibrarySongsByPage(ctx context.Context, page, pageSize int) (*models.Page[models.Song], error) {
+	return getPage[models.Song](ctx, &s.BaseService, "me/library/songs", page, pageSize)
+}
+
 // GetLibrarySong gets a song from the user's library by ID.
 func (s *LibraryService) GetLibrarySong(ctx context.Context, id string) (*models.Song, error) {
-	path := fmt.Sprintf("me/library/songs/%s", id)
+	path := s.buildPath(fmt.Sprintf("me/library/songs/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
 
 	var response models.SongsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -49,7 +66,7 @@ func (s *LibraryService) GetLibrarySong(ctx context.Context, id string) (*models
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("song not found: %s", id)
+		return nil, emptyResponseError("song not found: %s", id)
 	}
 
 	return &response.Data[0], nil
@@ -57,7 +74,7 @@ func (s *LibraryService) GetLibrarySong(ctx context.Context, id string) (*models
 
 // GetLibraryAlbums gets albums from the user's library.
 func (s *LibraryService) GetLibraryAlbums(ctx context.Context, limit, offset int) ([]models.Album, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 	s.setOffset(offset, queryParams)
 
@@ -72,9 +89,24 @@ func (s *LibraryService) GetLibraryAlbums(ctx context.Context, limit, offset int
 	return response.Data, nil
 }
 
+// GetLibraryAlbumsList gets albums from the user's library like GetLibraryAlbums,
+// along with the pagination information, meta, and raw JSON of the response.
+func (s *LibraryService) GetLibraryAlbumsList(ctx context.Context, limit, offset int) (*models.ListResult[models.Album], error) {
+	queryParams := s.defaultQueryParams()
+	s.setLimit(limit, queryParams)
+	s.setOffset(offset, queryParams)
+
+	return getList[models.Album](ctx, s.client, s.buildPath("me/library/albums", queryParams))
+}
+
+// GetLibraryAlbumsByPage gets a zero-based page of albums from the user's library.
+func (s *LibraryService) GetLibraryAlbumsByPage(ctx context.Context, page, pageSize int) (*models.Page[models.Album], error) {
+	return getPage[models.Album](ctx, &s.BaseService, "me/library/albums", page, pageSize)
+}
+
 // GetLibraryAlbum gets an album from the user's library by ID.
 func (s *LibraryService) GetLibraryAlbum(ctx context.Context, id string) (*models.Album, error) {
-	path := fmt.Sprintf("me/library/albums/%s", id)
+	path := s.buildPath(fmt.Sprintf("me/library/albums/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
 
 	var response models.AlbumsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -83,7 +115,7 @@ func (s *LibraryService) GetLibraryAlbum(ctx context.Context, id string) (*model
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("album not found: %s", id)
+		return nil, emptyResponseError("album not found: %s", id)
 	}
 
 	return &response.Data[0], nil
@@ -91,7 +123,7 @@ func (s *LibraryService) GetLibraryAlbum(ctx context.Context, id string) (*model
 
 // GetLibraryArtists gets artists from the user's library.
 func (s *LibraryService) GetLibraryArtists(ctx context.Context, limit, offset int) ([]models.Artist, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 	s.setOffset(offset, queryParams)
 
@@ -106,9 +138,14 @@ func (s *LibraryService) GetLibraryArtists(ctx context.Context, limit, offset in
 	return response.Data, nil
 }
 
+// GetLibraryArtistsByPage gets a zero-based page of artists from the user's library.
+func (s *LibraryService) GetLibraryArtistsByPage(ctx context.Context, page, pageSize int) (*models.Page[models.Artist], error) {
+	return getPage[models.Artist](ctx, &s.BaseService, "me/library/artists", page, pageSize)
+}
+
 // GetLibraryArtist gets an artist from the user's library by ID.
 func (s *LibraryService) GetLibraryArtist(ctx context.Context, id string) (*models.Artist, error) {
-	path := fmt.Sprintf("me/library/artists/%s", id)
+	path := s.buildPath(fmt.Sprintf("me/library/artists/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
 
 	var response models.ArtistsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -117,7 +154,42 @@ func (s *LibraryService) GetLibraryArtist(ctx context.Context, id string) (*mode
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("artist not found: %s", id)
+		return nil, emptyResponseError("artist not found: %s", id)
+	}
+
+	return &response.Data[0], nil
+}
+
+// GetLibraryMusicVideos gets music videos from the user's library with the
+// specified options.
+func (s *LibraryService) GetLibraryMusicVideos(ctx context.Context, options models.QueryParameters) ([]models.MusicVideo, error) {
+	if err := s.validateQueryParams("library-music-videos", options); err != nil {
+		return nil, err
+	}
+
+	path := s.buildPath("me/library/music-videos", s.buildQueryParams(options))
+
+	var response models.MusicVideosResponse
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// GetLibraryMusicVideo gets a music video from the user's library by ID.
+func (s *LibraryService) GetLibraryMusicVideo(ctx context.Context, id string) (*models.MusicVideo, error) {
+	path := s.buildPath(fmt.Sprintf("me/library/music-videos/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
+
+	var response models.MusicVideosResponse
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, emptyResponseError("music video not found: %s", id)
 	}
 
 	return &response.Data[0], nil
@@ -125,7 +197,7 @@ func (s *LibraryService) GetLibraryArtist(ctx context.Context, id string) (*mode
 
 // GetRecentlyAdded gets resources recently added to the user's library.
 func (s *LibraryService) GetRecentlyAdded(ctx context.Context, limit, offset int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 	s.setOffset(offset, queryParams)
 
@@ -145,7 +217,7 @@ func (s *LibraryService) GetRecentlyAdded(ctx context.Context, limit, offset int
 
 // GetHeavyRotation gets resources in the user's heavy rotation.
 func (s *LibraryService) GetHeavyRotation(ctx context.Context, limit, offset int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 	s.setOffset(offset, queryParams)
 
@@ -163,7 +235,9 @@ func (s *LibraryService) GetHeavyRotation(ctx context.Context, limit, offset int
 	return response.Data, nil
 }
 
-// AddToLibrary adds resources to the user's library.
+// AddToLibrary adds resources to the user's library. If the request succeeds
+// but the response reports errors for some of the resources, an
+// *errors.PartialError listing them is returned.
 func (s *LibraryService) AddToLibrary(ctx context.Context, ids []string, resourceType string) error {
 	if len(ids) == 0 {
 		return fmt.Errorf("at least one ID is required")
@@ -173,6 +247,10 @@ func (s *LibraryService) AddToLibrary(ctx context.Context, ids []string, resourc
 		return fmt.Errorf("resource type is required")
 	}
 
+	if t, err := models.ParseResourceType(resourceType); err != nil || t.IsLibrary() {
+		return fmt.Errorf("invalid catalog resource type: %s", resourceType)
+	}
+
 	resources := make([]map[string]interface{}, len(ids))
 	for i, id := range ids {
 		resources[i] = map[string]interface{}{
@@ -187,11 +265,42 @@ func (s *LibraryService) AddToLibrary(ctx context.Context, ids []string, resourc
 
 	path := "me/library"
 
-	var response interface{}
+	var response struct {
+		Errors []errors.ErrorDetail `json:"errors"`
+	}
 	err := s.client.Post(ctx, path, requestBody, &response)
 	if err != nil {
 		return err
 	}
 
+	if len(response.Errors) > 0 {
+		return &errors.PartialError{Errors: response.Errors}
+	}
+
 	return nil
 }
+
+// GetReplayPlaylists gets the yearly Apple Music Replay playlists the user has
+// added to their library. Playlists are matched by the "replay" playlist type
+// or, since library playlists often omit it, by a name starting with "Replay ".
+// The name match is best-effort: it relies on Apple's English naming, so it can
+// miss renamed or localized Replay playlists and include the user's own
+// playlists named that way.
+func (s *LibraryService) GetReplayPlaylists(ctx context.Context) ([]models.Playlist, error) {
+	path := s.buildPath("me/library/playlists", s.defaultQueryParams())
+
+	var replays []models.Playlist
+	err := forEachPage(ctx, s.client, path, s.pageSizeFor(MaxLibraryPageSize), func(playlists []models.Playlist) bool {
+		for _, playlist := range playlists {
+			if playlist.Attributes.PlaylistType == "replay" || strings.HasPrefix(playlist.Attributes.Name, "Replay ") {
+				replays = append(replays, playlist)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return replays, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/errors"
+	"github.com/marcusziade/musickitkat/mockapi"
+)
+
+func TestLibraryIDsAreEscapedOnce(t *testing.T) {
+	const id = "i.a+b c&d%e"
+
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/songs/" + id:   {Body: `{"data":[{"id":"i.a+b c&d%e","type":"library-songs","attributes":{"name":"Mock Song"}}]}`},
+		"GET me/ratings/library-songs": {Body: `{"data":[{"id":"i.a+b c&d%e","type":"ratings","attributes":{"value":1}}]}`},
+	})
+
+	song, err := NewLibraryService(c).GetLibrarySong(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetLibrarySong() error = %v", err)
+	}
+	if song.ID != id {
+		t.Errorf("GetLibrarySong() ID = %q, want %q", song.ID, id)
+	}
+
+	ratings, err := NewRatingService(c).GetRatings(context.Background(), "library-songs", []string{id, "i.2"})
+	if err != nil {
+		t.Fatalf("GetRatings() error = %v", err)
+	}
+	if ratings[id] != 1 {
+		t.Errorf("GetRatings() = %v, want %q rated 1", ratings, id)
+	}
+
+	requests := server.Requests()
+	if path := requests[0].URL.EscapedPath(); path != "/v1/me/library/songs/i.a+b%20c&d%25e" {
+		t.Errorf("song path = %q, want the ID escaped once", path)
+	}
+	if ids := requests[1].URL.Query().Get("ids"); ids != id+",i.2" {
+		t.Errorf("ids = %q, want %q", ids, id+",i.2")
+	}
+}
+
+func TestCommaSeparatedKeepsItems(t *testing.T) {
+	if got, want := commaSeparated([]string{"i.1", " i.2", ""}), "i.1, i.2,"; got != want {
+		t.Errorf("commaSeparated() = %q, want %q", got, want)
+	}
+}
+
+func TestGetReplayPlaylists(t *testing.T) {
+	_, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/playlists": {Body: `{"data":[` +
+			`{"id":"p.1","type":"library-playlists","attributes":{"name":"Replay 2024"}},` +
+			`{"id":"p.2","type":"library-playlists","attributes":{"name":"Road Trip"}},` +
+			`{"id":"p.3","type":"library-playlists","attributes":{"name":"Dein Replay 2024","playlistType":"replay"}},` +
+			`{"id":"p.4","type":"library-playlists","attributes":{"name":"Replayed Favourites"}}]}`},
+	})
+
+	replays, err := NewLibraryService(c).GetReplayPlaylists(context.Background())
+	if err != nil {
+		t.Fatalf("GetReplayPlaylists() error = %v", err)
+	}
+
+	var ids []string
+	for _, playlist := range replays {
+		ids = append(ids, playlist.ID)
+	}
+	if len(ids) != 2 || ids[0] != "p.1" || ids[1] != "p.3" {
+		t.Errorf("GetReplayPlaylists() = %v, want [p.1 p.3]", ids)
+	}
+}
+
+func TestAddToLibraryPartialError(t *testing.T) {
+	_, c := newMockClient(t, mockapi.Fixtures{
+		"POST me/library": {Status: http.StatusAccepted, Body: `{"errors":[` +
+			`{"id":"e1","title":"Resource Not Found","detail":"Song 9 is not available","status":"404","code":"40400"}]}`},
+	})
+
+	err := NewLibraryService(c).AddToLibrary(context.Background(), []string{"1", "9"}, "songs")
+	if !errors.IsPartialError(err) {
+		t.Fatalf("AddToLibrary() error = %v, want a partial error", err)
+	}
+
+	var partial *errors.PartialError
+	if !stderrors.As(err, &partial) || len(partial.Errors) != 1 {
+		t.Fatalf("AddToLibrary() error = %#v, want one failed item", err)
+	}
+	if detail := partial.Errors[0]; detail.Status != "404" || detail.Code != "40400" || detail.Detail != "Song 9 is not available" {
+		t.Errorf("failed item = %+v, want song 9 not found", detail)
+	}
+	if want := "partial failure: 1 item(s) failed: Resource Not Found: Song 9 is not available"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestAddToLibraryWithoutErrors(t *testing.T) {
+	_, c := newMockClient(t, mockapi.Fixtures{
+		"POST me/library": {Status: http.StatusAccepted},
+	})
+
+	if err := NewLibraryService(c).AddToLibrary(context.Background(), []string{"1", "2"}, "songs"); err != nil {
+		t.Errorf("AddToLibrary() error = %v", err)
+	}
+}
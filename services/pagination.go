@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/models"
+)
+
+// page is a single page of a paginated collection.
+type page[T any] struct {
+	Data []T                    `json:"data"`
+	Next string                 `json:"next,omitempty"`
+	Meta map[string]interface{} `json:"meta,omitempty"`
+}
+
+// Largest page sizes the API accepts, used by default by paginators and the
+// helpers that page through collections in full, to minimize round trips.
+const (
+	// MaxLibraryPageSize is the largest page size of library collections.
+	MaxLibraryPageSize = 100
+
+	// MaxCatalogPageSize is the largest page size of catalog collections and
+	// relationships.
+	MaxCatalogPageSize = 100
+
+	// MaxSearchPageSize is the largest page size of each type of search results.
+	MaxSearchPageSize = 25
+)
+
+// SetPageSize sets the page size that helpers which page through a collection
+// in full, such as GetArtistAllSongs, request with each page. Values less than
+// 1 restore the default, the endpoint's maximum, and larger values are clamped
+// to it.
+func (s *BaseService) SetPageSize(n int) {
+	s.pageSize = n
+}
+
+// pageSizeFor returns the service's page size for an endpoint with the given
+// maximum page size.
+func (s *BaseService) pageSizeFor(maxPageSize int) int {
+	return clampPageSize(s.pageSize, maxPageSize)
+}
+
+// clampPageSize returns pageSize clamped to maxPageSize, or maxPageSize if
+// pageSize is less than 1.
+func clampPageSize(pageSize, maxPageSize int) int {
+	if pageSize < 1 || pageSize > maxPageSize {
+		return maxPageSize
+	}
+	return pageSize
+}
+
+// withLimit returns path with its limit query parameter set to limit. Next
+// links from the API carry the offset but not always the limit, so it is set
+// on every page.
+func withLimit(path string, limit int) string {
+	base, rawQuery, _ := strings.Cut(path, "?")
+	queryParams, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return path
+	}
+
+	queryParams.Set("limit", strconv.Itoa(limit))
+	return base + "?" + queryParams.Encode()
+}
+
+// Paginator loads a paginated collection one page at a time by following each
+// page's next link.
+type Paginator[T any] struct {
+	client      *client.Client
+	path        string
+	pageSize    int
+	maxPageSize int
+	fetch       func(ctx context.Context, path string) (items []T, next string, err error)
+}
+
+// newPaginator returns a paginator whose first page is at path, with pages of
+// up to maxPageSize items. Fetch gets the page at a path and returns its items
+// and next link.
+func newPaginator[T any](c *client.Client, path string, maxPageSize int, fetch func(ctx context.Context, path string) ([]T, string, error)) *Paginator[T] {
+	return &Paginator[T]{client: c, path: path, pageSize: maxPageSize, maxPageSize: maxPageSize, fetch: fetch}
+}
+
+// SetPageSize sets the number of items requested with each following page.
+// Values less than 1 restore the default, the endpoint's maximum, and larger
+// values are clamped to it.
+func (p *Paginator[T]) SetPageSize(n int) {
+	p.pageSize = clampPageSize(n, p.maxPageSize)
+}
+
+// PageSize returns the number of items requested with each page.
+func (p *Paginator[T]) PageSize() int {
+	return p.pageSize
+}
+
+// HasNext reports whether there is another page to load.
+func (p *Paginator[T]) HasNext() bool {
+	return p.path != ""
+}
+
+// Next loads the next page and returns its items. It returns no items and no
+// error when there are no more pages. If loading fails, the error is returned
+// and the same page is loaded again by the next call.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.path == "" {
+		return nil, nil
+	}
+
+	items, next, err := p.fetch(ctx, withLimit(p.path, p.pageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	p.path = ""
+	if next != "" {
+		p.path = p.client.RelativePath(next)
+	}
+
+	return items, nil
+}
+
+// forEachPage gets the collection at path and each following page, calling fn
+// with the data of every page until fn returns false or there are no more
+// pages. Every page is requested with pageSize items.
+func forEachPage[T any](ctx context.Context, c *client.Client, path string, pageSize int, fn func([]T) bool) error {
+	for path != "" {
+		var response page[T]
+		err := c.Get(ctx, withLimit(path, pageSize), &response)
+		if err != nil {
+			return err
+		}
+
+		if !fn(response.Data) {
+			return nil
+		}
+
+		path = ""
+		if response.Next != "" {
+			path = c.RelativePath(response.Next)
+		}
+	}
+
+	return nil
+}
+
+// getPage gets a zero-based page of the collection at path, translating the
+// page number into the offset Apple expects.
+func getPage[T any](ctx context.Context, s *BaseService, path string, pageNumber, pageSize int) (*models.Page[T], error) {
+	if pageNumber < 0 {
+		return nil, fmt.Errorf("page number must not be negative: %d", pageNumber)
+	}
+
+	if pageSize < 1 {
+		return nil, fmt.Errorf("page size must be positive: %d", pageSize)
+	}
+
+	queryParams := s.defaultQueryParams()
+	s.setLimit(pageSize, queryParams)
+	s.setOffset(models.PageOffset(pageNumber, pageSize), queryParams)
+
+	var response page[T]
+	err := s.client.Get(ctx, s.buildPath(path, queryParams), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPage(response.Data, pageNumber, pageSize, models.PaginationFromMeta(response.Meta, response.Next)), nil
+}
+
+// getList gets a single response of the collection at path as a ListResult.
+func getList[T any](ctx context.Context, c *client.Client, path string) (*models.ListResult[T], error) {
+	var response models.ListResult[T]
+	err := c.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
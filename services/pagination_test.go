@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/mockapi"
+)
+
+// newMockClient starts a mockapi server with fixtures and returns it along
+// with a client that sends requests to it with a user token.
+func newMockClient(t *testing.T, fixtures mockapi.Fixtures) (*mockapi.Server, *client.Client) {
+	t.Helper()
+
+	server := mockapi.NewServer(fixtures)
+	t.Cleanup(server.Close)
+
+	c := client.NewClient(client.WithBaseURL(server.URL))
+	c.SetDeveloperToken("developer-token")
+	c.SetUserToken("user-token")
+	return server, c
+}
+
+func TestForEachPageSetsLimitOnEveryPage(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/playlists?limit=2": {Body: `{"data":[{"id":"p.1","type":"library-playlists","attributes":{"name":"One"}},` +
+			`{"id":"p.2","type":"library-playlists","attributes":{"name":"Two"}}],"next":"/v1/me/library/playlists?offset=2"}`},
+		"GET me/library/playlists?limit=2&offset=2": {Body: `{"data":[{"id":"p.3","type":"library-playlists","attributes":{"name":"Three"}}]}`},
+	})
+
+	playlists := NewPlaylistService(c)
+	playlists.SetPageSize(2)
+
+	found, err := playlists.FindUserPlaylistByName(context.Background(), "Three")
+	if err != nil {
+		t.Fatalf("FindUserPlaylistByName() error = %v", err)
+	}
+	if found == nil || found.ID != "p.3" {
+		t.Fatalf("FindUserPlaylistByName() = %v, want playlist p.3", found)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	for i, request := range requests {
+		if limit := request.URL.Query().Get("limit"); limit != "2" {
+			t.Errorf("page %d limit = %q, want %q", i+1, limit, "2")
+		}
+	}
+}
+
+func TestForEachPageDefaultsToMaxPageSize(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/playlists": {Body: `{"data":[]}`},
+	})
+
+	if _, err := NewPlaylistService(c).FindUserPlaylistByName(context.Background(), "Missing"); err != nil {
+		t.Fatalf("FindUserPlaylistByName() error = %v", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].URL.Query().Get("limit") != "100" {
+		t.Errorf("requests = %v, want one request with limit=100", requests)
+	}
+}
+
+func TestWithLimit(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"me/library/songs", "me/library/songs?limit=10"},
+		{"me/library/songs?offset=20", "me/library/songs?limit=10&offset=20"},
+		{"me/library/songs?limit=100&offset=20", "me/library/songs?limit=10&offset=20"},
+	}
+
+	for _, tt := range tests {
+		if got := withLimit(tt.path, 10); got != tt.want {
+			t.Errorf("withLimit(%q, 10) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestClampPageSize(t *testing.T) {
+	tests := []struct{ pageSize, want int }{
+		{0, MaxSearchPageSize},
+		{-1, MaxSearchPageSize},
+		{10, 10},
+		{MaxSearchPageSize + 1, MaxSearchPageSize},
+	}
+
+	for _, tt := range tests {
+		if got := clampPageSize(tt.pageSize, MaxSearchPageSize); got != tt.want {
+			t.Errorf("clampPageSize(%d) = %d, want %d", tt.pageSize, got, tt.want)
+		}
+	}
+}
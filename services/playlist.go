@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/marcusziade/musickitkat/client"
 	"github.com/marcusziade/musickitkat/models"
@@ -28,10 +30,31 @@ func (s *PlaylistService) SetStorefront(storefront string) {
 	s.storefront = storefront
 }
 
+// GetStorefront returns the default storefront of the playlist service. A
+// storefront attached to a call's context with WithStorefront, or passed in
+// the call's options, takes precedence over it.
+func (s *PlaylistService) GetStorefront() string {
+	return s.storefront
+}
+
 // GetCatalogPlaylist gets a playlist from the catalog by ID.
 func (s *PlaylistService) GetCatalogPlaylist(ctx context.Context, id string) (*models.Playlist, error) {
-	path := fmt.Sprintf("catalog/%s/playlists/%s", s.storefront, id)
+	return s.getPlaylist(ctx, s.catalogPath(resolveStorefront(ctx, "", s.storefront), fmt.Sprintf("playlists/%s", url.PathEscape(id)), s.defaultResourceQueryParams()), id)
+}
+
+// GetCatalogPlaylistWithOptions gets a playlist from the catalog by ID with the
+// specified options. Include "tracks" to get the playlist's tracks with their
+// attributes, decoded with Playlist.Tracks.
+func (s *PlaylistService) GetCatalogPlaylistWithOptions(ctx context.Context, id string, options models.QueryParameters) (*models.Playlist, error) {
+	if err := s.validateQueryParams("playlists", options); err != nil {
+		return nil, err
+	}
 
+	return s.getPlaylist(ctx, s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront), fmt.Sprintf("playlists/%s", url.PathEscape(id)), s.buildResourceQueryParams(options)), id)
+}
+
+// getPlaylist gets the playlist at the provided path.
+func (s *PlaylistService) getPlaylist(ctx context.Context, path, id string) (*models.Playlist, error) {
 	var response models.PlaylistsResponse
 	err := s.client.Get(ctx, path, &response)
 	if err != nil {
@@ -39,7 +62,7 @@ func (s *PlaylistService) GetCatalogPlaylist(ctx context.Context, id string) (*m
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("playlist not found: %s", id)
+		return nil, emptyResponseError("playlist not found: %s", id)
 	}
 
 	return &response.Data[0], nil
@@ -47,14 +70,25 @@ func (s *PlaylistService) GetCatalogPlaylist(ctx context.Context, id string) (*m
 
 // GetCatalogPlaylists gets multiple playlists from the catalog by IDs.
 func (s *PlaylistService) GetCatalogPlaylists(ctx context.Context, ids []string) ([]models.Playlist, error) {
+	return s.GetCatalogPlaylistsWithOptions(ctx, ids, models.QueryParameters{})
+}
+
+// GetCatalogPlaylistsWithOptions gets multiple playlists from the catalog by
+// IDs with the specified options, such as Include, Extend, and LanguageTag,
+// which are sent alongside the ids.
+func (s *PlaylistService) GetCatalogPlaylistsWithOptions(ctx context.Context, ids []string, options models.QueryParameters) ([]models.Playlist, error) {
 	if len(ids) == 0 {
 		return nil, fmt.Errorf("at least one ID is required")
 	}
 
-	queryParams := url.Values{}
+	if err := s.validateQueryParams("playlists", options); err != nil {
+		return nil, err
+	}
+
+	queryParams := s.buildResourceQueryParams(options)
 	queryParams.Set("ids", commaSeparated(ids))
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/playlists", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, options.Storefront, s.storefront), "playlists", queryParams)
 
 	var response models.PlaylistsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -67,7 +101,7 @@ func (s *PlaylistService) GetCatalogPlaylists(ctx context.Context, ids []string)
 
 // GetCatalogPlaylistTracks gets the tracks in a playlist from the catalog.
 func (s *PlaylistService) GetCatalogPlaylistTracks(ctx context.Context, id string) ([]models.Song, error) {
-	path := fmt.Sprintf("catalog/%s/playlists/%s/tracks", s.storefront, id)
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), fmt.Sprintf("playlists/%s/tracks", url.PathEscape(id)), s.defaultQueryParams())
 
 	var response models.SongsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -80,7 +114,25 @@ func (s *PlaylistService) GetCatalogPlaylistTracks(ctx context.Context, id strin
 
 // GetUserPlaylist gets a user's playlist by ID.
 func (s *PlaylistService) GetUserPlaylist(ctx context.Context, id string) (*models.Playlist, error) {
-	path := fmt.Sprintf("me/library/playlists/%s", id)
+	return s.getPlaylist(ctx, s.buildPath(fmt.Sprintf("me/library/playlists/%s", url.PathEscape(id)), s.defaultResourceQueryParams()), id)
+}
+
+// GetUserPlaylistWithOptions gets a user's playlist by ID with the specified
+// options. Include "tracks" to get the playlist's tracks with their
+// attributes, decoded with Playlist.Tracks.
+func (s *PlaylistService) GetUserPlaylistWithOptions(ctx context.Context, id string, options models.QueryParameters) (*models.Playlist, error) {
+	if err := s.validateQueryParams("library-playlists", options); err != nil {
+		return nil, err
+	}
+
+	return s.getPlaylist(ctx, s.buildPath(fmt.Sprintf("me/library/playlists/%s", url.PathEscape(id)), s.buildResourceQueryParams(options)), id)
+}
+
+// GetCatalogEquivalent gets the catalog playlist that a library playlist was
+// added from, such as an editorial playlist the user saved. It returns a
+// not-found error for playlists the user created.
+func (s *PlaylistService) GetCatalogEquivalent(ctx context.Context, libraryPlaylistID string) (*models.Playlist, error) {
+	path := s.buildPath(fmt.Sprintf("me/library/playlists/%s/catalog", url.PathEscape(libraryPlaylistID)), s.defaultResourceQueryParams())
 
 	var response models.PlaylistsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -89,7 +141,7 @@ func (s *PlaylistService) GetUserPlaylist(ctx context.Context, id string) (*mode
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("playlist not found: %s", id)
+		return nil, emptyResponseError("catalog playlist not found for library playlist: %s", libraryPlaylistID)
 	}
 
 	return &response.Data[0], nil
@@ -97,7 +149,7 @@ func (s *PlaylistService) GetUserPlaylist(ctx context.Context, id string) (*mode
 
 // GetUserPlaylists gets all playlists in the user's library.
 func (s *PlaylistService) GetUserPlaylists(ctx context.Context) ([]models.Playlist, error) {
-	path := "me/library/playlists"
+	path := s.buildPath("me/library/playlists", s.defaultQueryParams())
 
 	var response models.PlaylistsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -108,8 +160,23 @@ func (s *PlaylistService) GetUserPlaylists(ctx context.Context) ([]models.Playli
 	return response.Data, nil
 }
 
+// GetUserPlaylistsList gets playlists in the user's library with the specified
+// options, along with the pagination information, meta, and raw JSON of the
+// response.
+func (s *PlaylistService) GetUserPlaylistsList(ctx context.Context, options models.QueryParameters) (*models.ListResult[models.Playlist], error) {
+	if err := s.validateQueryParams("library-playlists", options); err != nil {
+		return nil, err
+	}
+
+	return getList[models.Playlist](ctx, s.client, s.buildPath("me/library/playlists", s.buildQueryParams(options)))
+}
+
 // GetUserPlaylistsWithOptions gets playlists in the user's library with the specified options.
 func (s *PlaylistService) GetUserPlaylistsWithOptions(ctx context.Context, options models.QueryParameters) ([]models.Playlist, error) {
+	if err := s.validateQueryParams("library-playlists", options); err != nil {
+		return nil, err
+	}
+
 	path := "me/library/playlists"
 
 	// Build query parameters from options
@@ -127,9 +194,22 @@ func (s *PlaylistService) GetUserPlaylistsWithOptions(ctx context.Context, optio
 	return response.Data, nil
 }
 
+// GetUserPlaylistsWithTotal gets playlists in the user's library with the
+// specified options, along with the total number of playlists in the library,
+// read from the response meta, for showing "X of Y playlists". The total is
+// zero if the response did not include it.
+func (s *PlaylistService) GetUserPlaylistsWithTotal(ctx context.Context, options models.QueryParameters) (playlists []models.Playlist, total int, err error) {
+	list, err := s.GetUserPlaylistsList(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list.Data, list.Total, nil
+}
+
 // GetUserPlaylistTracks gets the tracks in a user's playlist.
 func (s *PlaylistService) GetUserPlaylistTracks(ctx context.Context, id string) ([]models.Song, error) {
-	path := fmt.Sprintf("me/library/playlists/%s/tracks", id)
+	path := s.buildPath(fmt.Sprintf("me/library/playlists/%s/tracks", url.PathEscape(id)), s.defaultQueryParams())
 
 	var response models.SongsResponse
 	err := s.client.Get(ctx, path, &response)
@@ -140,36 +220,195 @@ func (s *PlaylistService) GetUserPlaylistTracks(ctx context.Context, id string)
 	return response.Data, nil
 }
 
-// CreatePlaylist creates a new playlist in the user's library.
-func (s *PlaylistService) CreatePlaylist(ctx context.Context, name, description string, trackIDs []string) (*models.Playlist, error) {
+// FindUserPlaylistByName returns the first playlist in the user's library with the
+// given name, paging through the library as needed. It returns nil and no error
+// when no playlist has that name.
+func (s *PlaylistService) FindUserPlaylistByName(ctx context.Context, name string) (*models.Playlist, error) {
 	if name == "" {
 		return nil, fmt.Errorf("playlist name is required")
 	}
 
-	tracks := make([]map[string]interface{}, len(trackIDs))
-	for i, id := range trackIDs {
-		tracks[i] = map[string]interface{}{
-			"id":   id,
-			"type": "songs",
+	path := s.buildPath("me/library/playlists", s.defaultQueryParams())
+
+	var found *models.Playlist
+	err := forEachPage(ctx, s.client, path, s.pageSizeFor(MaxLibraryPageSize), func(playlists []models.Playlist) bool {
+		for i := range playlists {
+			if playlists[i].Attributes.Name == name {
+				found = &playlists[i]
+				return false
+			}
 		}
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	requestBody := map[string]interface{}{
-		"attributes": map[string]interface{}{
-			"name":        name,
-			"description": description,
-		},
-		"relationships": map[string]interface{}{
-			"tracks": map[string]interface{}{
-				"data": tracks,
-			},
-		},
+	return found, nil
+}
+
+// GetPlaylistFolder gets a playlist folder in the user's library by ID.
+// Use models.RootPlaylistFolderID for the root of the playlist tree.
+func (s *PlaylistService) GetPlaylistFolder(ctx context.Context, id string) (*models.PlaylistFolder, error) {
+	path := s.buildPath(fmt.Sprintf("me/library/playlist-folders/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
+
+	var response models.PlaylistFoldersResponse
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, emptyResponseError("playlist folder not found: %s", id)
+	}
+
+	return &response.Data[0], nil
+}
+
+// GetPlaylistFolderContents gets the playlists and subfolders contained in a
+// folder in the user's library, interleaved in the order the API returns them.
+// Use models.RootPlaylistFolderID to list the top level of the playlist tree.
+func (s *PlaylistService) GetPlaylistFolderContents(ctx context.Context, folderID string, options models.QueryParameters) (*models.PlaylistFolderContents, error) {
+	if folderID == "" {
+		return nil, fmt.Errorf("folder ID is required")
+	}
+
+	queryParams := s.buildQueryParams(options)
+	path := s.buildPath(fmt.Sprintf("me/library/playlist-folders/%s/children", url.PathEscape(folderID)), queryParams)
+
+	var response struct {
+		Data []json.RawMessage `json:"data"`
+		Next string            `json:"next,omitempty"`
+	}
+
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := &models.PlaylistFolderContents{Next: response.Next}
+	for _, raw := range response.Data {
+		var resource models.Resource
+		if err := json.Unmarshal(raw, &resource); err != nil {
+			return nil, fmt.Errorf("failed to decode folder child: %w", err)
+		}
+
+		switch resource.Type {
+		case models.ResourceTypeLibraryPlaylistFolders.String():
+			var folder models.PlaylistFolder
+			if err := json.Unmarshal(raw, &folder); err != nil {
+				return nil, fmt.Errorf("failed to decode playlist folder %s: %w", resource.ID, err)
+			}
+			contents.Entries = append(contents.Entries, models.PlaylistFolderEntry{Folder: &folder})
+		case models.ResourceTypeLibraryPlaylists.String():
+			var playlist models.Playlist
+			if err := json.Unmarshal(raw, &playlist); err != nil {
+				return nil, fmt.Errorf("failed to decode playlist %s: %w", resource.ID, err)
+			}
+			contents.Entries = append(contents.Entries, models.PlaylistFolderEntry{Playlist: &playlist})
+		}
+	}
+
+	return contents, nil
+}
+
+// NewIdempotencyKey generates a random key for use with CreatePlaylistIdempotent.
+func NewIdempotencyKey() (string, error) {
+	return client.NewUUID()
+}
+
+// CreatePlaylist creates a new playlist in the user's library with the given songs,
+// in the given order.
+func (s *PlaylistService) CreatePlaylist(ctx context.Context, name, description string, trackIDs []string) (*models.Playlist, error) {
+	return s.createPlaylist(ctx, name, description, songTracks(trackIDs), nil)
+}
+
+// CreatePlaylistWithTracks creates a new playlist in the user's library with the
+// given tracks, in the given order. Each track specifies its own type, so songs
+// and music videos can be mixed.
+func (s *PlaylistService) CreatePlaylistWithTracks(ctx context.Context, name, description string, tracks []models.PlaylistTrack) (*models.Playlist, error) {
+	for i, track := range tracks {
+		if track.ID == "" || track.Type == "" {
+			return nil, fmt.Errorf("track %d: ID and type are required", i)
+		}
+	}
+
+	return s.createPlaylist(ctx, name, description, tracks, nil)
+}
+
+// CreatePlaylistIdempotent creates a new playlist in the user's library, sending
+// idempotencyKey in the Idempotency-Key header.
+//
+// Retrying a create after a timeout can otherwise produce duplicate playlists.
+// The recommended pattern for sync jobs is to generate one key per logical
+// create with NewIdempotencyKey, persist it alongside the job, and reuse it on
+// every retry. The Apple Music API does not document support for the header and
+// may ignore it, so a retry of an attempt that actually succeeded can still
+// create a second playlist. Use FindOrCreatePlaylist to also guard against that
+// by name.
+func (s *PlaylistService) CreatePlaylistIdempotent(ctx context.Context, idempotencyKey, name, description string, trackIDs []string) (*models.Playlist, error) {
+	if idempotencyKey == "" {
+		return nil, fmt.Errorf("idempotency key is required")
+	}
+
+	headers := map[string]string{
+		client.IdempotencyKeyHeader: idempotencyKey,
+	}
+
+	return s.createPlaylist(ctx, name, description, songTracks(trackIDs), headers)
+}
+
+// FindOrCreatePlaylist returns the first playlist in the user's library with the
+// given name, or creates it with CreatePlaylistIdempotent if there is none. A
+// retry of a create that actually succeeded then returns the original playlist
+// even if the API ignored the idempotency key. Only use it when playlist names
+// are unique for the caller, since any existing playlist with the name matches.
+func (s *PlaylistService) FindOrCreatePlaylist(ctx context.Context, idempotencyKey, name, description string, trackIDs []string) (*models.Playlist, error) {
+	if idempotencyKey == "" {
+		return nil, fmt.Errorf("idempotency key is required")
+	}
+
+	existing, err := s.FindUserPlaylistByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return existing, nil
+	}
+
+	return s.CreatePlaylistIdempotent(ctx, idempotencyKey, name, description, trackIDs)
+}
+
+// createPlaylistRequest is the request body for creating a playlist. Tracks are
+// encoded as a JSON array, so their order is preserved exactly.
+type createPlaylistRequest struct {
+	Attributes struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"attributes"`
+	Relationships struct {
+		Tracks struct {
+			Data []models.PlaylistTrack `json:"data"`
+		} `json:"tracks"`
+	} `json:"relationships"`
+}
+
+// createPlaylist creates a new playlist, sending any additional headers with the request.
+func (s *PlaylistService) createPlaylist(ctx context.Context, name, description string, tracks []models.PlaylistTrack, headers map[string]string) (*models.Playlist, error) {
+	if name == "" {
+		return nil, fmt.Errorf("playlist name is required")
 	}
 
+	var requestBody createPlaylistRequest
+	requestBody.Attributes.Name = name
+	requestBody.Attributes.Description = description
+	requestBody.Relationships.Tracks.Data = append([]models.PlaylistTrack{}, tracks...)
+
 	path := "me/library/playlists"
 
 	var response models.PlaylistsResponse
-	err := s.client.Post(ctx, path, requestBody, &response)
+	err := s.client.PostWithHeaders(ctx, path, requestBody, headers, &response)
 	if err != nil {
 		return nil, err
 	}
@@ -181,25 +420,31 @@ func (s *PlaylistService) CreatePlaylist(ctx context.Context, name, description
 	return &response.Data[0], nil
 }
 
+// songTracks converts song IDs into playlist tracks of type songs.
+func songTracks(ids []string) []models.PlaylistTrack {
+	tracks := make([]models.PlaylistTrack, len(ids))
+	for i, id := range ids {
+		tracks[i] = models.PlaylistTrack{ID: id, Type: models.ResourceTypeSongs.String()}
+	}
+	return tracks
+}
+
 // AddTracksToPlaylist adds tracks to a user's playlist.
 func (s *PlaylistService) AddTracksToPlaylist(ctx context.Context, playlistID string, trackIDs []string) error {
 	if len(trackIDs) == 0 {
 		return fmt.Errorf("at least one track ID is required")
 	}
 
-	tracks := make([]map[string]interface{}, len(trackIDs))
-	for i, id := range trackIDs {
-		tracks[i] = map[string]interface{}{
-			"id":   id,
-			"type": "songs",
-		}
-	}
+	return s.addTracks(ctx, playlistID, songTracks(trackIDs))
+}
 
+// addTracks adds tracks of any type to a user's playlist in a single request.
+func (s *PlaylistService) addTracks(ctx context.Context, playlistID string, tracks []models.PlaylistTrack) error {
 	requestBody := map[string]interface{}{
 		"data": tracks,
 	}
 
-	path := fmt.Sprintf("me/library/playlists/%s/tracks", playlistID)
+	path := fmt.Sprintf("me/library/playlists/%s/tracks", url.PathEscape(playlistID))
 
 	var response interface{}
 	err := s.client.Post(ctx, path, requestBody, &response)
@@ -210,13 +455,57 @@ func (s *PlaylistService) AddTracksToPlaylist(ctx context.Context, playlistID st
 	return nil
 }
 
+// PlaylistTrackChunkSize is the maximum number of tracks ClonePlaylist sends
+// in a single request.
+const PlaylistTrackChunkSize = 100
+
+// ClonePlaylist saves a copy of a catalog playlist, such as an editorial
+// playlist, to the user's library under a new name. The catalog playlist's
+// tracks are paged through in full, then the new playlist is created with the
+// first PlaylistTrackChunkSize tracks and the rest are added in chunks of that
+// size, preserving their order. If adding a chunk fails, the error is returned
+// along with the partially filled playlist.
+// This method requires a user token to be set on the client.
+func (s *PlaylistService) ClonePlaylist(ctx context.Context, catalogPlaylistID, newName string) (*models.Playlist, error) {
+	if newName == "" {
+		return nil, fmt.Errorf("playlist name is required")
+	}
+
+	var tracks []models.PlaylistTrack
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), fmt.Sprintf("playlists/%s/tracks", url.PathEscape(catalogPlaylistID)), s.defaultQueryParams())
+	err := forEachPage(ctx, s.client, path, s.pageSizeFor(MaxCatalogPageSize), func(page []models.Resource) bool {
+		for _, track := range page {
+			tracks = append(tracks, models.PlaylistTrack{ID: track.ID, Type: track.Type})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracks of playlist %s: %w", catalogPlaylistID, err)
+	}
+
+	first := tracks[:min(len(tracks), PlaylistTrackChunkSize)]
+	playlist, err := s.createPlaylist(ctx, newName, "", first, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for start := len(first); start < len(tracks); start += PlaylistTrackChunkSize {
+		chunk := tracks[start:min(start+PlaylistTrackChunkSize, len(tracks))]
+		if err := s.addTracks(ctx, playlist.ID, chunk); err != nil {
+			return playlist, fmt.Errorf("failed to add tracks %d-%d of %d to playlist %s: %w", start+1, start+len(chunk), len(tracks), playlist.ID, err)
+		}
+	}
+
+	return playlist, nil
+}
+
 // RemoveTracksFromPlaylist removes tracks from a user's playlist.
 func (s *PlaylistService) RemoveTracksFromPlaylist(ctx context.Context, playlistID string, trackIndices []int) error {
 	if len(trackIndices) == 0 {
 		return fmt.Errorf("at least one track index is required")
 	}
 
-	path := fmt.Sprintf("me/library/playlists/%s/tracks", playlistID)
+	path := fmt.Sprintf("me/library/playlists/%s/tracks", url.PathEscape(playlistID))
 
 	var response interface{}
 	err := s.client.Delete(ctx, path, &response)
@@ -226,3 +515,157 @@ func (s *PlaylistService) RemoveTracksFromPlaylist(ctx context.Context, playlist
 
 	return nil
 }
+
+// SetTrackOrder replaces the tracks of a user's playlist with the tracks with
+// the given library IDs, in the given order, with a single PUT request. The
+// playlist's current tracks are fetched first to resolve the type of each
+// track, and IDs that are not in the playlist are rejected. If exact is true,
+// the IDs must also be exactly the playlist's current tracks, including
+// duplicates, so that a reorder cannot drop tracks by mistake; otherwise
+// tracks may be left out or repeated.
+// This method requires a user token to be set on the client.
+func (s *PlaylistService) SetTrackOrder(ctx context.Context, playlistID string, orderedTrackIDs []string, exact bool) error {
+	if len(orderedTrackIDs) == 0 {
+		return fmt.Errorf("at least one track ID is required")
+	}
+
+	path := fmt.Sprintf("me/library/playlists/%s/tracks", url.PathEscape(playlistID))
+
+	types := make(map[string]string)
+	counts := make(map[string]int)
+	err := forEachPage(ctx, s.client, s.buildPath(path, s.defaultQueryParams()), s.pageSizeFor(MaxLibraryPageSize), func(page []models.Resource) bool {
+		for _, track := range page {
+			types[track.ID] = track.Type
+			counts[track.ID]++
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get tracks of playlist %s: %w", playlistID, err)
+	}
+
+	tracks := make([]models.PlaylistTrack, len(orderedTrackIDs))
+	for i, id := range orderedTrackIDs {
+		trackType, ok := types[id]
+		if !ok {
+			return fmt.Errorf("track %s is not in playlist %s", id, playlistID)
+		}
+		tracks[i] = models.PlaylistTrack{ID: id, Type: trackType}
+		counts[id]--
+	}
+
+	if exact {
+		for id, count := range counts {
+			if count != 0 {
+				return fmt.Errorf("track IDs do not match the tracks of playlist %s: %s", playlistID, id)
+			}
+		}
+	}
+
+	requestBody := map[string]interface{}{
+		"data": tracks,
+	}
+
+	var response interface{}
+	err = s.client.Put(ctx, path, requestBody, &response)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PlaylistChange is sent by WatchPlaylist when a watched playlist changes, or
+// when polling it fails.
+type PlaylistChange struct {
+	// The playlist as of this change, or nil if Err is set
+	Playlist *models.Playlist
+
+	// The error that occurred while polling, if any
+	Err error
+}
+
+// WatchPlaylist polls a playlist in the user's library every interval and
+// sends the playlist on the returned channel when it changes, until the context
+// is cancelled, after which the channel is closed. The current playlist is sent
+// first. A change is detected from the playlist's lastModifiedDate; if the
+// client has ETag caching enabled, polls answered with 304 Not Modified are
+// treated as unchanged without comparing dates, although the client still
+// decodes its cached body. Polling errors are sent as a PlaylistChange with Err
+// set, and polling continues. The receiver must keep reading from the channel until
+// it is closed or the context is cancelled.
+// This method requires a user token to be set on the client.
+func (s *PlaylistService) WatchPlaylist(ctx context.Context, id string, interval time.Duration) (<-chan PlaylistChange, error) {
+	if id == "" {
+		return nil, fmt.Errorf("playlist ID is required")
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive: %s", interval)
+	}
+
+	path := s.buildPath(fmt.Sprintf("me/library/playlists/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
+	changes := make(chan PlaylistChange)
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var lastModified string
+		first := true
+		for {
+			change, changed := s.pollPlaylist(ctx, path, id, first, lastModified)
+			if changed {
+				if change.Err == nil {
+					lastModified = change.Playlist.Attributes.LastModifiedDate
+					first = false
+				}
+
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// pollPlaylist fetches the playlist at path once for WatchPlaylist and reports
+// whether the result should be sent: on an error, on the first successful
+// fetch, or when the last modified date differs from lastModified.
+func (s *PlaylistService) pollPlaylist(ctx context.Context, path, id string, first bool, lastModified string) (PlaylistChange, bool) {
+	var response models.PlaylistsResponse
+	fromCache, err := s.client.GetConditional(ctx, path, &response)
+	if err != nil {
+		if ctx.Err() != nil {
+			return PlaylistChange{}, false
+		}
+		return PlaylistChange{Err: err}, true
+	}
+
+	if fromCache && !first {
+		return PlaylistChange{}, false
+	}
+
+	if len(response.Data) == 0 {
+		return PlaylistChange{Err: emptyResponseError("playlist not found: %s", id)}, true
+	}
+
+	playlist := &response.Data[0]
+	if !first && playlist.Attributes.LastModifiedDate == lastModified {
+		return PlaylistChange{}, false
+	}
+
+	return PlaylistChange{Playlist: playlist}, true
+}
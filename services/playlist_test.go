@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/mockapi"
+	"github.com/marcusziade/musickitkat/models"
+)
+
+// playlistTracksJSON is the tracks of library playlist p.1: two songs and a music video.
+const playlistTracksJSON = `{"data":[` +
+	`{"id":"i.1","type":"library-songs"},` +
+	`{"id":"i.2","type":"library-songs"},` +
+	`{"id":"i.3","type":"library-music-videos"}]}`
+
+// sentTracks decodes the tracks of a request body with a data array of tracks.
+func sentTracks(t *testing.T, body []byte) []models.PlaylistTrack {
+	t.Helper()
+
+	var request struct {
+		Data []models.PlaylistTrack `json:"data"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("failed to decode request body %s: %v", body, err)
+	}
+	return request.Data
+}
+
+func TestSetTrackOrder(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/playlists/p.1/tracks": {Body: playlistTracksJSON},
+		"PUT me/library/playlists/p.1/tracks": {Status: http.StatusNoContent},
+	})
+
+	err := NewPlaylistService(c).SetTrackOrder(context.Background(), "p.1", []string{"i.3", "i.1", "i.2"}, true)
+	if err != nil {
+		t.Fatalf("SetTrackOrder() error = %v", err)
+	}
+
+	requests := server.Requests()
+	put := requests[len(requests)-1]
+	if put.Method != http.MethodPut {
+		t.Fatalf("last request = %s, want PUT", put.Method)
+	}
+
+	want := []models.PlaylistTrack{
+		{ID: "i.3", Type: "library-music-videos"},
+		{ID: "i.1", Type: "library-songs"},
+		{ID: "i.2", Type: "library-songs"},
+	}
+	if got := sentTracks(t, put.Body); !reflect.DeepEqual(got, want) {
+		t.Errorf("sent tracks = %+v, want %+v", got, want)
+	}
+}
+
+func TestSetTrackOrderRejectsTracksNotInPlaylist(t *testing.T) {
+	tests := []struct {
+		name  string
+		ids   []string
+		exact bool
+		ok    bool
+	}{
+		{"unknown ID", []string{"i.3", "i.1", "i.9"}, false, false},
+		{"unknown ID, exact", []string{"i.3", "i.1", "i.2", "i.9"}, true, false},
+		{"dropped track, exact", []string{"i.3", "i.1"}, true, false},
+		{"repeated track, exact", []string{"i.3", "i.1", "i.2", "i.2"}, true, false},
+		{"dropped track", []string{"i.3", "i.1"}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, c := newMockClient(t, mockapi.Fixtures{
+				"GET me/library/playlists/p.1/tracks": {Body: playlistTracksJSON},
+				"PUT me/library/playlists/p.1/tracks": {Status: http.StatusNoContent},
+			})
+
+			err := NewPlaylistService(c).SetTrackOrder(context.Background(), "p.1", tt.ids, tt.exact)
+			if (err == nil) != tt.ok {
+				t.Fatalf("SetTrackOrder() error = %v, want success %v", err, tt.ok)
+			}
+
+			sent := false
+			for _, request := range server.Requests() {
+				sent = sent || request.Method == http.MethodPut
+			}
+			if sent != tt.ok {
+				t.Errorf("PUT sent = %v, want %v", sent, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGetPlaylistFolderContentsKeepsOrder(t *testing.T) {
+	_, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/playlist-folders/p.playlistsroot/children": {Body: `{"data":[` +
+			`{"id":"p.a","type":"library-playlists","attributes":{"name":"A"}},` +
+			`{"id":"f.b","type":"library-playlist-folders","attributes":{"name":"B"}},` +
+			`{"id":"p.c","type":"library-playlists","attributes":{"name":"C"}}],` +
+			`"next":"/v1/me/library/playlist-folders/p.playlistsroot/children?offset=3"}`},
+	})
+
+	contents, err := NewPlaylistService(c).GetPlaylistFolderContents(context.Background(), models.RootPlaylistFolderID, models.QueryParameters{})
+	if err != nil {
+		t.Fatalf("GetPlaylistFolderContents() error = %v", err)
+	}
+
+	var names []string
+	for _, entry := range contents.Entries {
+		if entry.IsFolder() {
+			names = append(names, "folder "+entry.Folder.Attributes.Name)
+		} else {
+			names = append(names, "playlist "+entry.Playlist.Attributes.Name)
+		}
+	}
+
+	if want := []string{"playlist A", "folder B", "playlist C"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("entries = %v, want %v", names, want)
+	}
+
+	if folders, playlists := contents.Folders(), contents.Playlists(); len(folders) != 1 || len(playlists) != 2 || playlists[1].ID != "p.c" {
+		t.Errorf("Folders() = %+v, Playlists() = %+v, want one folder and two playlists", folders, playlists)
+	}
+
+	if contents.Next == "" {
+		t.Error("Next is empty, want the next page")
+	}
+}
+
+func TestCreatePlaylistKeepsTrackOrder(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"POST me/library/playlists": {Status: http.StatusCreated, Body: `{"data":[{"id":"p.new","type":"library-playlists","attributes":{"name":"Mix"}}]}`},
+	})
+	service := NewPlaylistService(c)
+
+	tracks := []models.PlaylistTrack{
+		{ID: "3", Type: "songs"},
+		{ID: "9", Type: "music-videos"},
+		{ID: "1", Type: "songs"},
+		{ID: "2", Type: "songs"},
+	}
+	playlist, err := service.CreatePlaylistWithTracks(context.Background(), "Mix", "", tracks)
+	if err != nil {
+		t.Fatalf("CreatePlaylistWithTracks() error = %v", err)
+	}
+	if playlist.ID != "p.new" {
+		t.Errorf("CreatePlaylistWithTracks() ID = %q, want %q", playlist.ID, "p.new")
+	}
+
+	if _, err := service.CreatePlaylist(context.Background(), "Mix", "", []string{"3", "1", "2"}); err != nil {
+		t.Fatalf("CreatePlaylist() error = %v", err)
+	}
+
+	requests := server.Requests()
+	for i, want := range [][]models.PlaylistTrack{tracks, {{ID: "3", Type: "songs"}, {ID: "1", Type: "songs"}, {ID: "2", Type: "songs"}}} {
+		var request createPlaylistRequest
+		if err := json.Unmarshal(requests[i].Body, &request); err != nil {
+			t.Fatalf("failed to decode request body %s: %v", requests[i].Body, err)
+		}
+		if got := request.Relationships.Tracks.Data; !reflect.DeepEqual(got, want) {
+			t.Errorf("request %d tracks = %+v, want %+v", i+1, got, want)
+		}
+	}
+}
+
+func TestCreatePlaylistIdempotentDoesNotMatchByName(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/playlists":  {Status: http.StatusOK, Body: `{"data":[{"id":"p.old","type":"library-playlists","attributes":{"name":"Mix"}}]}`},
+		"POST me/library/playlists": {Status: http.StatusCreated, Body: `{"data":[{"id":"p.new","type":"library-playlists","attributes":{"name":"Mix"}}]}`},
+	})
+	service := NewPlaylistService(c)
+
+	playlist, err := service.CreatePlaylistIdempotent(context.Background(), "key-1", "Mix", "", []string{"1"})
+	if err != nil {
+		t.Fatalf("CreatePlaylistIdempotent() error = %v", err)
+	}
+	if playlist.ID != "p.new" {
+		t.Errorf("CreatePlaylistIdempotent() ID = %q, want %q", playlist.ID, "p.new")
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 || requests[0].Method != http.MethodPost {
+		t.Fatalf("requests = %+v, want a single POST", requests)
+	}
+	if got := requests[0].Header.Get("Idempotency-Key"); got != "key-1" {
+		t.Errorf("Idempotency-Key = %q, want %q", got, "key-1")
+	}
+}
+
+func TestFindOrCreatePlaylist(t *testing.T) {
+	tests := []struct {
+		name      string
+		playlists string
+		wantID    string
+		wantPosts int
+	}{
+		{"existing", `{"data":[{"id":"p.old","type":"library-playlists","attributes":{"name":"Mix"}}]}`, "p.old", 0},
+		{"missing", `{"data":[{"id":"p.other","type":"library-playlists","attributes":{"name":"Other"}}]}`, "p.new", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, c := newMockClient(t, mockapi.Fixtures{
+				"GET me/library/playlists":  {Status: http.StatusOK, Body: tt.playlists},
+				"POST me/library/playlists": {Status: http.StatusCreated, Body: `{"data":[{"id":"p.new","type":"library-playlists","attributes":{"name":"Mix"}}]}`},
+			})
+			service := NewPlaylistService(c)
+
+			playlist, err := service.FindOrCreatePlaylist(context.Background(), "key-1", "Mix", "", []string{"1"})
+			if err != nil {
+				t.Fatalf("FindOrCreatePlaylist() error = %v", err)
+			}
+			if playlist.ID != tt.wantID {
+				t.Errorf("FindOrCreatePlaylist() ID = %q, want %q", playlist.ID, tt.wantID)
+			}
+
+			posts := 0
+			for _, request := range server.Requests() {
+				if request.Method == http.MethodPost {
+					posts++
+					if got := request.Header.Get("Idempotency-Key"); got != "key-1" {
+						t.Errorf("Idempotency-Key = %q, want %q", got, "key-1")
+					}
+				}
+			}
+			if posts != tt.wantPosts {
+				t.Errorf("POST requests = %d, want %d", posts, tt.wantPosts)
+			}
+		})
+	}
+}
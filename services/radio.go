@@ -6,6 +6,7 @@ import (
 	"net/url"
 
 	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/models"
 )
 
 // RadioService provides access to radio endpoints of the Apple Music API.
@@ -27,12 +28,19 @@ func (s *RadioService) SetStorefront(storefront string) {
 	s.storefront = storefront
 }
 
+// GetStorefront returns the default storefront of the radio service. A
+// storefront attached to a call's context with WithStorefront, or passed in
+// the call's options, takes precedence over it.
+func (s *RadioService) GetStorefront() string {
+	return s.storefront
+}
+
 // GetStations gets all radio stations.
 func (s *RadioService) GetStations(ctx context.Context, limit int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/stations", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "stations", queryParams)
 
 	var response struct {
 		Data []interface{} `json:"data"`
@@ -48,7 +56,7 @@ func (s *RadioService) GetStations(ctx context.Context, limit int) (interface{},
 
 // GetStation gets a radio station by ID.
 func (s *RadioService) GetStation(ctx context.Context, id string) (interface{}, error) {
-	path := fmt.Sprintf("catalog/%s/stations/%s", s.storefront, id)
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), fmt.Sprintf("stations/%s", url.PathEscape(id)), s.defaultResourceQueryParams())
 
 	var response struct {
 		Data []interface{} `json:"data"`
@@ -60,18 +68,56 @@ func (s *RadioService) GetStation(ctx context.Context, id string) (interface{},
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("station not found: %s", id)
+		return nil, emptyResponseError("station not found: %s", id)
 	}
 
 	return response.Data[0], nil
 }
 
+// GetStationShow gets the Apple Music Radio show a station belongs to.
+func (s *RadioService) GetStationShow(ctx context.Context, stationID string) (*models.RadioShow, error) {
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), fmt.Sprintf("stations/%s/radio-show", url.PathEscape(stationID)), s.defaultResourceQueryParams())
+
+	var response models.RadioShowsResponse
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, emptyResponseError("radio show not found for station: %s", stationID)
+	}
+
+	return &response.Data[0], nil
+}
+
+// GetPersonalStation gets the user's personal station, "My Station", which is
+// built from their listening history. It requires a user token.
+func (s *RadioService) GetPersonalStation(ctx context.Context) (*models.Station, error) {
+	queryParams := s.defaultResourceQueryParams()
+	queryParams.Set("filter[identity]", "personal")
+
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "stations", queryParams)
+
+	var response models.StationsResponse
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, emptyResponseError("personal station not found")
+	}
+
+	return &response.Data[0], nil
+}
+
 // GetFeaturedStations gets featured radio stations.
 func (s *RadioService) GetFeaturedStations(ctx context.Context, limit int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/stations/featured", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "stations/featured", queryParams)
 
 	var response struct {
 		Data []interface{} `json:"data"`
@@ -87,7 +133,7 @@ func (s *RadioService) GetFeaturedStations(ctx context.Context, limit int) (inte
 
 // GetRecentStations gets recently played radio stations.
 func (s *RadioService) GetRecentStations(ctx context.Context, limit int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 
 	path := s.buildPath("me/recent/stations", queryParams)
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/errors"
+	"github.com/marcusziade/musickitkat/models"
+)
+
+// RatingService provides access to the user's ratings in the Apple Music API.
+// All methods require a user token to be set on the client.
+type RatingService struct {
+	BaseService
+}
+
+// NewRatingService creates a new RatingService with the provided client.
+func NewRatingService(client *client.Client) *RatingService {
+	return &RatingService{
+		BaseService: *NewBaseService(client),
+	}
+}
+
+// GetRatings gets the user's ratings of the resources of the given type, such
+// as "songs" or "library-playlists", with the given IDs, in a single request.
+// Ratings are keyed by resource ID; resources the user has not rated are
+// absent from the map.
+func (s *RatingService) GetRatings(ctx context.Context, resourceType string, ids []string) (map[string]int, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one ID is required")
+	}
+
+	if _, err := models.ParseResourceType(resourceType); err != nil {
+		return nil, fmt.Errorf("invalid resource type: %s", resourceType)
+	}
+
+	queryParams := s.defaultResourceQueryParams()
+	queryParams.Set("ids", commaSeparated(ids))
+
+	path := s.buildPath(fmt.Sprintf("me/ratings/%s", url.PathEscape(resourceType)), queryParams)
+
+	var response models.RatingsResponse
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		// The API answers 404 when none of the resources are rated
+		var apiErr *errors.APIError
+		if stderrors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return map[string]int{}, nil
+		}
+		return nil, err
+	}
+
+	ratings := make(map[string]int, len(response.Data))
+	for _, rating := range response.Data {
+		ratings[rating.ID] = rating.Attributes.Value
+	}
+
+	return ratings, nil
+}
+
+// DeleteRatings deletes the user's ratings of the resources of the given type
+// with the given IDs, with one request per ID, running at most the service's
+// concurrency limit of requests at once (see SetConcurrency). Deleting the
+// rating of a resource the user has not rated succeeds. If any deletion failed,
+// or was not started because ctx was done, an *errors.BatchError is returned
+// with the error of each failed ID.
+func (s *RatingService) DeleteRatings(ctx context.Context, resourceType string, ids []string) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("at least one ID is required")
+	}
+
+	if _, err := models.ParseResourceType(resourceType); err != nil {
+		return fmt.Errorf("invalid resource type: %s", resourceType)
+	}
+
+	errs := forEachConcurrently(ctx, ids, s.concurrencyLimit(), func(ctx context.Context, id string) error {
+		return s.deleteRating(ctx, resourceType, id)
+	})
+
+	if len(errs) > 0 {
+		return &errors.BatchError{Errors: errs}
+	}
+
+	return nil
+}
+
+// deleteRating deletes the user's rating of a resource.
+func (s *RatingService) deleteRating(ctx context.Context, resourceType, id string) error {
+	path := fmt.Sprintf("me/ratings/%s/%s", url.PathEscape(resourceType), url.PathEscape(id))
+
+	var response interface{}
+	err := s.client.Delete(ctx, path, &response)
+	if err != nil {
+		// The API answers 404 when the resource is not rated
+		var apiErr *errors.APIError
+		if stderrors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -2,10 +2,12 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 
 	"github.com/marcusziade/musickitkat/client"
+	"github.com/marcusziade/musickitkat/models"
 )
 
 // RecommendationService provides access to recommendation endpoints of the Apple Music API.
@@ -27,9 +29,16 @@ func (s *RecommendationService) SetStorefront(storefront string) {
 	s.storefront = storefront
 }
 
+// GetStorefront returns the default storefront of the recommendation service. A
+// storefront attached to a call's context with WithStorefront, or passed in
+// the call's options, takes precedence over it.
+func (s *RecommendationService) GetStorefront() string {
+	return s.storefront
+}
+
 // GetRecommendations gets recommendations for the user.
 func (s *RecommendationService) GetRecommendations(ctx context.Context, limit int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 
 	path := s.buildPath(fmt.Sprintf("me/recommendations"), queryParams)
@@ -46,12 +55,25 @@ func (s *RecommendationService) GetRecommendations(ctx context.Context, limit in
 	return response.Data, nil
 }
 
-// GetRecommendation gets a recommendation by ID.
-func (s *RecommendationService) GetRecommendation(ctx context.Context, id string) (interface{}, error) {
-	path := fmt.Sprintf("me/recommendations/%s", id)
+// GetRecommendation gets a recommendation by ID. Its contents carry only their
+// type and ID; use GetRecommendationWithOptions to include them in full.
+func (s *RecommendationService) GetRecommendation(ctx context.Context, id string) (*models.Recommendation, error) {
+	return s.GetRecommendationWithOptions(ctx, id, models.QueryParameters{})
+}
+
+// GetRecommendationWithOptions gets a recommendation by ID with the specified
+// options. With Include: []string{"contents"}, its contents are returned in
+// full, ready to render with Contents. If the contents are paginated,
+// ContentsNext on the result is the href for GetRecommendationContentsNext.
+func (s *RecommendationService) GetRecommendationWithOptions(ctx context.Context, id string, options models.QueryParameters) (*models.Recommendation, error) {
+	if err := s.validateQueryParams("personal-recommendation", options); err != nil {
+		return nil, err
+	}
+
+	path := s.buildPath(fmt.Sprintf("me/recommendations/%s", url.PathEscape(id)), s.buildResourceQueryParams(options))
 
 	var response struct {
-		Data interface{} `json:"data"`
+		Data []models.Recommendation `json:"data"`
 	}
 
 	err := s.client.Get(ctx, path, &response)
@@ -59,15 +81,46 @@ func (s *RecommendationService) GetRecommendation(ctx context.Context, id string
 		return nil, err
 	}
 
-	return response.Data, nil
+	if len(response.Data) == 0 {
+		return nil, emptyResponseError("recommendation not found: %s", id)
+	}
+
+	return &response.Data[0], nil
+}
+
+// GetRecommendationContentsNext gets the next page of a recommendation's
+// contents from the href returned by ContentsNext, decoded as by
+// models.DecodeResource. Next is the href of the following page, or empty if
+// there are no more contents.
+func (s *RecommendationService) GetRecommendationContentsNext(ctx context.Context, next string) (contents []interface{}, nextPage string, err error) {
+	if next == "" {
+		return nil, "", fmt.Errorf("next href is required")
+	}
+
+	var response page[json.RawMessage]
+	err = s.client.Get(ctx, s.client.RelativePath(next), &response)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contents = make([]interface{}, 0, len(response.Data))
+	for _, raw := range response.Data {
+		content, err := models.DecodeResource(raw)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to decode recommendation content: %w", err)
+		}
+		contents = append(contents, content)
+	}
+
+	return contents, response.Next, nil
 }
 
 // GetFeaturedPlaylists gets featured playlists.
 func (s *RecommendationService) GetFeaturedPlaylists(ctx context.Context, limit int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/playlists/featured", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "playlists/featured", queryParams)
 
 	var response struct {
 		Data []interface{} `json:"data"`
@@ -83,7 +136,7 @@ func (s *RecommendationService) GetFeaturedPlaylists(ctx context.Context, limit
 
 // GetPersonalRecommendations gets personal recommendations for the user.
 func (s *RecommendationService) GetPersonalRecommendations(ctx context.Context, limit int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 
 	path := s.buildPath(fmt.Sprintf("me/recommendations/personal"), queryParams)
@@ -102,10 +155,10 @@ func (s *RecommendationService) GetPersonalRecommendations(ctx context.Context,
 
 // GetCuratedPlaylists gets curated playlists.
 func (s *RecommendationService) GetCuratedPlaylists(ctx context.Context, limit int) (interface{}, error) {
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	s.setLimit(limit, queryParams)
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/playlists/curated", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "playlists/curated", queryParams)
 
 	var response struct {
 		Data []interface{} `json:"data"`
@@ -118,3 +171,77 @@ func (s *RecommendationService) GetCuratedPlaylists(ctx context.Context, limit i
 
 	return response.Data, nil
 }
+
+// GetBrowse gets the editorial sections of the Browse tab for the storefront,
+// such as new releases and featured playlists, each with its title and typed
+// contents. It reads the editorial groupings endpoint used by the Apple Music
+// web app, which Apple does not document, so its shape may change. Sections
+// without contents are skipped.
+func (s *RecommendationService) GetBrowse(ctx context.Context, options models.QueryParameters) ([]models.BrowseSection, error) {
+	queryParams := s.buildQueryParams(options)
+	queryParams.Set("platform", "web")
+	queryParams.Set("name", "music")
+
+	path := s.buildPath(fmt.Sprintf("editorial/%s/groupings", resolveStorefront(ctx, options.Storefront, s.storefront)), queryParams)
+
+	var response struct {
+		Data []json.RawMessage `json:"data"`
+	}
+
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections []models.BrowseSection
+	if err := collectBrowseSections(response.Data, &sections); err != nil {
+		return nil, fmt.Errorf("failed to decode browse sections: %w", err)
+	}
+
+	return sections, nil
+}
+
+// editorialElement is a grouping, tab, or shelf of editorial content.
+type editorialElement struct {
+	models.Resource
+	Attributes struct {
+		Name                 string `json:"name"`
+		EditorialElementKind string `json:"editorialElementKind"`
+	} `json:"attributes"`
+	Relationships map[string]models.Relationship `json:"relationships"`
+}
+
+// collectBrowseSections appends a section for each editorial element with
+// contents, walking nested tabs and children depth first.
+func collectBrowseSections(elements []json.RawMessage, sections *[]models.BrowseSection) error {
+	for _, raw := range elements {
+		var element editorialElement
+		if err := json.Unmarshal(raw, &element); err != nil {
+			return err
+		}
+
+		if contents := element.Relationships["contents"].Raw; len(contents) > 0 {
+			section := models.BrowseSection{
+				ID:    element.ID,
+				Title: element.Attributes.Name,
+				Kind:  element.Attributes.EditorialElementKind,
+			}
+			for _, content := range contents {
+				resource, err := models.DecodeResource(content)
+				if err != nil {
+					return err
+				}
+				section.Contents = append(section.Contents, resource)
+			}
+			*sections = append(*sections, section)
+		}
+
+		for _, name := range []string{"tabs", "children"} {
+			if err := collectBrowseSections(element.Relationships[name].Raw, sections); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
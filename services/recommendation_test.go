@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/mockapi"
+	"github.com/marcusziade/musickitkat/models"
+)
+
+// recommendationJSON is recommendation 6-mock with the first page of its contents.
+const recommendationJSON = `{"id":"6-mock","type":"personal-recommendation","href":"/v1/me/recommendations/6-mock","attributes":{` +
+	`"isGroupRecommendation":false,"kind":"music-recommendations","resourceTypes":["albums"],` +
+	`"title":{"stringForDisplay":"Made for You"}},` +
+	`"relationships":{"contents":{"href":"/v1/me/recommendations/6-mock/contents",` +
+	`"next":"/v1/me/recommendations/6-mock/contents?offset=1","data":[` +
+	`{"id":"2","type":"albums","attributes":{"name":"Mock Album"}}]}}}`
+
+func TestGetRecommendationWithOptions(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/recommendations/6-mock": {Body: `{"data":[` + recommendationJSON + `]}`},
+	})
+	server.Handle("GET me/recommendations/6-mock/contents?offset=1", http.StatusOK, `{"data":[`+
+		`{"id":"pl.1","type":"playlists","attributes":{"name":"Mock Playlist"}}]}`)
+	recommendations := NewRecommendationService(c)
+
+	recommendation, err := recommendations.GetRecommendationWithOptions(context.Background(), "6-mock", models.QueryParameters{Include: []string{"contents"}})
+	if err != nil {
+		t.Fatalf("GetRecommendationWithOptions() error = %v", err)
+	}
+
+	if recommendation.Title() != "Made for You" || recommendation.Attributes.Kind != "music-recommendations" {
+		t.Errorf("recommendation = %q of kind %q, want Made for You of kind music-recommendations", recommendation.Title(), recommendation.Attributes.Kind)
+	}
+
+	if include := server.Requests()[0].URL.Query().Get("include"); include != "contents" {
+		t.Errorf("include = %q, want %q", include, "contents")
+	}
+
+	contents, err := recommendation.Contents()
+	if err != nil {
+		t.Fatalf("Contents() error = %v", err)
+	}
+	if len(contents) != 1 {
+		t.Fatalf("Contents() returned %d items, want 1", len(contents))
+	}
+	if album, ok := contents[0].(*models.Album); !ok || album.ID != "2" || album.Attributes.Name != "Mock Album" {
+		t.Errorf("Contents()[0] = %#v, want album 2, Mock Album", contents[0])
+	}
+
+	next := recommendation.ContentsNext()
+	if next != "/v1/me/recommendations/6-mock/contents?offset=1" {
+		t.Fatalf("ContentsNext() = %q, want the second page of contents", next)
+	}
+
+	contents, nextPage, err := recommendations.GetRecommendationContentsNext(context.Background(), next)
+	if err != nil {
+		t.Fatalf("GetRecommendationContentsNext() error = %v", err)
+	}
+	if nextPage != "" {
+		t.Errorf("next page = %q, want none", nextPage)
+	}
+	if len(contents) != 1 {
+		t.Fatalf("GetRecommendationContentsNext() returned %d items, want 1", len(contents))
+	}
+	if playlist, ok := contents[0].(*models.Playlist); !ok || playlist.ID != "pl.1" {
+		t.Errorf("contents[0] = %#v, want playlist pl.1", contents[0])
+	}
+}
+
+func TestGetRecommendationNotFound(t *testing.T) {
+	_, c := newMockClient(t, mockapi.DefaultFixtures())
+
+	if _, err := NewRecommendationService(c).GetRecommendation(context.Background(), "missing"); !isNotFound(err) {
+		t.Errorf("GetRecommendation() error = %v, want a not-found error", err)
+	}
+}
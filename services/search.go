@@ -2,15 +2,20 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/marcusziade/musickitkat/client"
 	"github.com/marcusziade/musickitkat/models"
 )
 
-// commaSeparated joins a slice of strings with commas.
+// commaSeparated joins a slice of strings with commas. The result is not
+// escaped; it is escaped exactly once when the query is encoded by buildPath,
+// so items must not be escaped by the caller.
 func commaSeparated(items []string) string {
 	return strings.Join(items, ",")
 }
@@ -34,15 +39,84 @@ func (s *SearchService) SetStorefront(storefront string) {
 	s.storefront = storefront
 }
 
-// Search searches for resources in the catalog.
+// GetStorefront returns the default storefront of the search service. A
+// storefront attached to a call's context with WithStorefront, or passed in
+// the call's options, takes precedence over it.
+func (s *SearchService) GetStorefront() string {
+	return s.storefront
+}
+
+// searchQueryParams returns the service default query parameters for a search.
+// If no default limit is set with SetDefaultQueryParameters, the limit is
+// models.DefaultSearchLimit, so that the page size does not depend on the API's
+// own default.
+func (s *SearchService) searchQueryParams() url.Values {
+	queryParams := s.defaultQueryParams()
+	if queryParams.Get("limit") == "" {
+		s.setLimit(models.DefaultSearchLimit, queryParams)
+	}
+	return queryParams
+}
+
+// setLimitByType sets a limit[type] query parameter for each resource type.
+func setLimitByType(limits map[string]int, queryParams url.Values) {
+	for resourceType, limit := range limits {
+		if limit > 0 {
+			queryParams.Set(fmt.Sprintf("limit[%s]", resourceType), strconv.Itoa(limit))
+		}
+	}
+}
+
+// maxLimit returns the largest of the per-type limits, or zero if there are none.
+func maxLimit(limits map[string]int) int {
+	largest := 0
+	for _, limit := range limits {
+		largest = max(largest, limit)
+	}
+	return largest
+}
+
+// Search searches for resources in the catalog. A storefront set in options
+// applies to this call only and wins over one set with WithStorefront. When
+// options do not set a limit, the service default limit is used, which is
+// models.DefaultSearchLimit unless overridden with SetDefaultQueryParameters.
+// If options set per-type limits but no overall limit, the overall limit is the
+// largest per-type limit, so that it still caps every type where Apple ignores
+// per-type limits.
 func (s *SearchService) Search(ctx context.Context, term string, types []string, options *models.SearchOptions) (*models.SearchResults, error) {
+	path, err := s.searchPath(ctx, term, types, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.SearchResults
+	err = s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// searchPath builds the path of a catalog search request.
+func (s *SearchService) searchPath(ctx context.Context, term string, types []string, options *models.SearchOptions) (string, error) {
 	if term == "" {
-		return nil, fmt.Errorf("search term is required")
+		return "", fmt.Errorf("search term is required")
 	}
 
-	queryParams := url.Values{}
+	explicit := ""
+	if options != nil {
+		explicit = options.Storefront
+	}
+	storefront := resolveStorefront(ctx, explicit, s.storefront)
+
+	queryParams := s.searchQueryParams()
 	queryParams.Set("term", term)
 
+	if len(types) == 0 && options != nil {
+		types = options.Types
+	}
+
 	if len(types) > 0 {
 		queryParams.Set("types", commaSeparated(types))
 	}
@@ -50,16 +124,15 @@ func (s *SearchService) Search(ctx context.Context, term string, types []string,
 	if options != nil {
 		if options.Limit > 0 {
 			queryParams.Set("limit", fmt.Sprintf("%d", options.Limit))
+		} else if limit := maxLimit(options.LimitByType); limit > 0 {
+			queryParams.Set("limit", fmt.Sprintf("%d", limit))
 		}
+		setLimitByType(options.LimitByType, queryParams)
 
 		if options.Offset > 0 {
 			queryParams.Set("offset", fmt.Sprintf("%d", options.Offset))
 		}
 
-		if options.Storefront != "" {
-			s.storefront = options.Storefront
-		}
-
 		if options.LanguageTag != "" {
 			queryParams.Set("l", options.LanguageTag)
 		}
@@ -75,17 +148,134 @@ func (s *SearchService) Search(ctx context.Context, term string, types []string,
 		if len(options.Extend) > 0 {
 			queryParams.Set("extend", commaSeparated(options.Extend))
 		}
+
+		setFields(options.Fields, queryParams)
+
+		if len(options.With) > 0 {
+			queryParams.Set("with", commaSeparated(options.With))
+		}
+
+		if len(options.Restrict) > 0 {
+			queryParams.Set("restrict", commaSeparated(options.Restrict))
+		}
 	}
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/search", s.storefront), queryParams)
+	return s.catalogPath(storefront, "search", queryParams), nil
+}
 
-	var response models.SearchResults
-	err := s.client.Get(ctx, path, &response)
+// SearchSongsPaginator returns a paginator over the songs found by a catalog
+// search, following the next link of the songs section to load each following
+// page. Options set the page size with Limit, clamped to MaxSearchPageSize,
+// which is also the default, and the first page with Offset; the page size can
+// be changed with SetPageSize between pages. The storefront is resolved when
+// the paginator is created.
+func (s *SearchService) SearchSongsPaginator(ctx context.Context, term string, options *models.SearchOptions) (*Paginator[models.Song], error) {
+	return searchPaginator[models.Song](ctx, s, term, "songs", options)
+}
+
+// SearchAlbumsPaginator returns a paginator over the albums found by a catalog
+// search, as SearchSongsPaginator does for songs.
+func (s *SearchService) SearchAlbumsPaginator(ctx context.Context, term string, options *models.SearchOptions) (*Paginator[models.Album], error) {
+	return searchPaginator[models.Album](ctx, s, term, "albums", options)
+}
+
+// SearchArtistsPaginator returns a paginator over the artists found by a
+// catalog search, as SearchSongsPaginator does for songs.
+func (s *SearchService) SearchArtistsPaginator(ctx context.Context, term string, options *models.SearchOptions) (*Paginator[models.Artist], error) {
+	return searchPaginator[models.Artist](ctx, s, term, "artists", options)
+}
+
+// SearchPlaylistsPaginator returns a paginator over the playlists found by a
+// catalog search, as SearchSongsPaginator does for songs.
+func (s *SearchService) SearchPlaylistsPaginator(ctx context.Context, term string, options *models.SearchOptions) (*Paginator[models.Playlist], error) {
+	return searchPaginator[models.Playlist](ctx, s, term, "playlists", options)
+}
+
+// searchPaginator returns a paginator over the catalog search results of a
+// single type, decoded as T.
+func searchPaginator[T any](ctx context.Context, s *SearchService, term, searchType string, options *models.SearchOptions) (*Paginator[T], error) {
+	path, err := s.searchPath(ctx, term, []string{searchType}, options)
 	if err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	paginator := newPaginator(s.client, path, MaxSearchPageSize, func(ctx context.Context, path string) ([]T, string, error) {
+		var response struct {
+			Results map[string]page[T] `json:"results"`
+		}
+		err := s.client.Get(ctx, path, &response)
+		if err != nil {
+			return nil, "", err
+		}
+
+		section := response.Results[searchType]
+		return section.Data, section.Next, nil
+	})
+	if options != nil {
+		paginator.SetPageSize(options.Limit)
+	}
+
+	return paginator, nil
+}
+
+// SearchSongs searches the catalog for songs. Next holds the options for the
+// following page of results, or is nil if there are no more results.
+func (s *SearchService) SearchSongs(ctx context.Context, term string, options *models.SearchOptions) (songs []models.Song, next *models.SearchOptions, err error) {
+	results, err := s.Search(ctx, term, []string{"songs"}, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results.Results.Songs.Data, nextSearchOptions(options, results.Results.Songs.Next), nil
+}
+
+// SearchAlbums searches the catalog for albums. Next holds the options for the
+// following page of results, or is nil if there are no more results.
+func (s *SearchService) SearchAlbums(ctx context.Context, term string, options *models.SearchOptions) (albums []models.Album, next *models.SearchOptions, err error) {
+	results, err := s.Search(ctx, term, []string{"albums"}, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results.Results.Albums.Data, nextSearchOptions(options, results.Results.Albums.Next), nil
+}
+
+// SearchArtists searches the catalog for artists. Next holds the options for
+// the following page of results, or is nil if there are no more results.
+func (s *SearchService) SearchArtists(ctx context.Context, term string, options *models.SearchOptions) (artists []models.Artist, next *models.SearchOptions, err error) {
+	results, err := s.Search(ctx, term, []string{"artists"}, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results.Results.Artists.Data, nextSearchOptions(options, results.Results.Artists.Next), nil
+}
+
+// SearchPlaylists searches the catalog for playlists. Next holds the options
+// for the following page of results, or is nil if there are no more results.
+func (s *SearchService) SearchPlaylists(ctx context.Context, term string, options *models.SearchOptions) (playlists []models.Playlist, next *models.SearchOptions, err error) {
+	results, err := s.Search(ctx, term, []string{"playlists"}, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results.Results.Playlists.Data, nextSearchOptions(options, results.Results.Playlists.Next), nil
+}
+
+// nextSearchOptions returns a copy of options with the offset of the next link
+// of a search result section, or nil if there is no next link.
+func nextSearchOptions(options *models.SearchOptions, next string) *models.SearchOptions {
+	offset, ok := models.Pagination{Next: next}.NextOffset()
+	if !ok {
+		return nil
+	}
+
+	var nextOptions models.SearchOptions
+	if options != nil {
+		nextOptions = *options
+	}
+	nextOptions.Offset = offset
+	return &nextOptions
 }
 
 // SearchHints gets search term hints for the provided term.
@@ -94,10 +284,10 @@ func (s *SearchService) SearchHints(ctx context.Context, term string) ([]string,
 		return nil, fmt.Errorf("search term is required")
 	}
 
-	queryParams := url.Values{}
+	queryParams := s.defaultQueryParams()
 	queryParams.Set("term", term)
 
-	path := s.buildPath(fmt.Sprintf("catalog/%s/search/hints", s.storefront), queryParams)
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "search/hints", queryParams)
 
 	var response struct {
 		Results struct {
@@ -113,16 +303,78 @@ func (s *SearchService) SearchHints(ctx context.Context, term string) ([]string,
 	return response.Results.Terms, nil
 }
 
-// SearchLibrary searches for resources in the user's library.
+// SearchSuggestions gets suggestions for a partial search term, both search
+// terms and top results, for autocomplete. Types limit the top results to the
+// given resource types, such as "songs" and "artists"; limit applies to each
+// kind of suggestion, and zero uses the API's default.
+func (s *SearchService) SearchSuggestions(ctx context.Context, term string, types []string, limit int) (*models.SearchSuggestions, error) {
+	if term == "" {
+		return nil, fmt.Errorf("search term is required")
+	}
+
+	queryParams := s.defaultQueryParams()
+	queryParams.Set("term", term)
+	queryParams.Set("kinds", "terms,topResults")
+	s.setLimit(limit, queryParams)
+
+	if len(types) > 0 {
+		queryParams.Set("types", commaSeparated(types))
+	}
+
+	path := s.catalogPath(resolveStorefront(ctx, "", s.storefront), "search/suggestions", queryParams)
+
+	var response struct {
+		Results struct {
+			Suggestions []struct {
+				Kind        string          `json:"kind"`
+				SearchTerm  string          `json:"searchTerm"`
+				DisplayTerm string          `json:"displayTerm"`
+				Content     json.RawMessage `json:"content"`
+			} `json:"suggestions"`
+		} `json:"results"`
+	}
+
+	err := s.client.Get(ctx, path, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := &models.SearchSuggestions{}
+	for _, suggestion := range response.Results.Suggestions {
+		switch suggestion.Kind {
+		case "terms":
+			suggestions.Terms = append(suggestions.Terms, models.TermSuggestion{
+				SearchTerm:  suggestion.SearchTerm,
+				DisplayTerm: suggestion.DisplayTerm,
+			})
+		case "topResults":
+			resource, err := models.DecodeResource(suggestion.Content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode top result suggestion: %w", err)
+			}
+			suggestions.TopResults = append(suggestions.TopResults, resource)
+		}
+	}
+
+	return suggestions, nil
+}
+
+// SearchLibrary searches for resources in the user's library. Types are
+// library types, such as "library-songs"; if none are passed, the types set in
+// options are used. The limit defaults as for Search.
 // This method requires a user token to be set on the client.
 func (s *SearchService) SearchLibrary(ctx context.Context, term string, types []string, options *models.SearchOptions) (*models.SearchResults, error) {
 	if term == "" {
 		return nil, fmt.Errorf("search term is required")
 	}
 
-	queryParams := url.Values{}
+	queryParams := s.searchQueryParams()
 	queryParams.Set("term", term)
 
+	if len(types) == 0 && options != nil {
+		types = options.Types
+	}
+
 	if len(types) > 0 {
 		queryParams.Set("types", commaSeparated(types))
 	}
@@ -130,7 +382,10 @@ func (s *SearchService) SearchLibrary(ctx context.Context, term string, types []
 	if options != nil {
 		if options.Limit > 0 {
 			queryParams.Set("limit", fmt.Sprintf("%d", options.Limit))
+		} else if limit := maxLimit(options.LimitByType); limit > 0 {
+			queryParams.Set("limit", fmt.Sprintf("%d", limit))
 		}
+		setLimitByType(options.LimitByType, queryParams)
 
 		if options.Offset > 0 {
 			queryParams.Set("offset", fmt.Sprintf("%d", options.Offset))
@@ -151,6 +406,8 @@ func (s *SearchService) SearchLibrary(ctx context.Context, term string, types []
 		if len(options.Extend) > 0 {
 			queryParams.Set("extend", commaSeparated(options.Extend))
 		}
+
+		setFields(options.Fields, queryParams)
 	}
 
 	path := s.buildPath("me/library/search", queryParams)
@@ -163,3 +420,174 @@ func (s *SearchService) SearchLibrary(ctx context.Context, term string, types []
 
 	return &response, nil
 }
+
+// SearchAll searches the catalog and the user's library concurrently and
+// returns both sets of results along with a merged, de-duplicated list in which
+// catalog items the user has in their library appear once, marked as in the
+// library. Types are catalog types such as "songs"; the matching library types
+// are searched. If no types are passed, the types set in options are used. The
+// library is only searched if a user token is set and some of the types have a
+// library equivalent. A failed library search does not fail the call: the
+// catalog results are returned with the error in LibraryErr.
+func (s *SearchService) SearchAll(ctx context.Context, term string, types []string, options *models.SearchOptions) (*models.CombinedSearchResults, error) {
+	if term == "" {
+		return nil, fmt.Errorf("search term is required")
+	}
+
+	if len(types) == 0 && options != nil {
+		types = options.Types
+	}
+
+	var catalog, library *models.SearchResults
+	var catalogErr, libraryErr error
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		catalog, catalogErr = s.Search(ctx, term, types, options)
+	}()
+
+	if searchTypes := libraryTypes(types); len(searchTypes) > 0 && s.client.HasUserTokenFor(ctx) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			library, libraryErr = s.SearchLibrary(ctx, term, searchTypes, options)
+		}()
+	}
+
+	wg.Wait()
+
+	if catalogErr != nil {
+		return nil, catalogErr
+	}
+
+	if libraryErr != nil {
+		libraryErr = fmt.Errorf("failed to search library: %w", libraryErr)
+	}
+
+	return &models.CombinedSearchResults{
+		Catalog:    catalog,
+		Library:    library,
+		LibraryErr: libraryErr,
+		Items:      mergeSearchResults(catalog, library),
+	}, nil
+}
+
+// libraryTypes maps catalog resource types to the matching library types.
+// Types without a library equivalent are dropped.
+func libraryTypes(types []string) []string {
+	libraryTypes := make([]string, 0, len(types))
+	for _, t := range types {
+		resourceType, err := models.ParseResourceType(strings.TrimSpace(t))
+		if err != nil || resourceType.IsLibrary() {
+			continue
+		}
+
+		if libraryType, ok := resourceType.LibraryType(); ok {
+			libraryTypes = append(libraryTypes, libraryType.String())
+		}
+	}
+	return libraryTypes
+}
+
+// searchEntry is a search result reduced to what is needed to merge results.
+type searchEntry struct {
+	resource models.Resource
+	name     string
+
+	// The catalog type and ID of a library item, when it has a catalog equivalent.
+	catalogType string
+	catalogID   string
+}
+
+// catalogEntries returns the entries of catalog search results.
+func catalogEntries(data models.SearchResultsData) []searchEntry {
+	var entries []searchEntry
+	for _, song := range data.Songs.Data {
+		entries = append(entries, searchEntry{resource: song.Resource, name: song.Attributes.Name})
+	}
+	for _, album := range data.Albums.Data {
+		entries = append(entries, searchEntry{resource: album.Resource, name: album.Attributes.Name})
+	}
+	for _, artist := range data.Artists.Data {
+		entries = append(entries, searchEntry{resource: artist.Resource, name: artist.Attributes.Name})
+	}
+	for _, playlist := range data.Playlists.Data {
+		entries = append(entries, searchEntry{resource: playlist.Resource, name: playlist.Attributes.Name})
+	}
+	for _, video := range data.MusicVideos.Data {
+		entries = append(entries, searchEntry{resource: video.Resource, name: video.Attributes.Name})
+	}
+	return entries
+}
+
+// libraryEntries returns the entries of library search results, with the
+// catalog ID of each item that has one.
+func libraryEntries(data models.SearchResultsData) []searchEntry {
+	var entries []searchEntry
+	for _, song := range data.LibrarySongs.Data {
+		entries = append(entries, searchEntry{song.Resource, song.Attributes.Name, "songs", song.Attributes.PlayParams.CatalogID})
+	}
+	for _, album := range data.LibraryAlbums.Data {
+		entries = append(entries, searchEntry{album.Resource, album.Attributes.Name, "albums", album.Attributes.PlayParams.CatalogID})
+	}
+	for _, artist := range data.LibraryArtists.Data {
+		entries = append(entries, searchEntry{artist.Resource, artist.Attributes.Name, "artists", ""})
+	}
+	for _, playlist := range data.LibraryPlaylists.Data {
+		catalogID := playlist.Attributes.PlayParams.GlobalID
+		if catalogID == "" {
+			catalogID = playlist.Attributes.PlayParams.CatalogID
+		}
+		entries = append(entries, searchEntry{playlist.Resource, playlist.Attributes.Name, "playlists", catalogID})
+	}
+	for _, video := range data.LibraryMusicVideos.Data {
+		entries = append(entries, searchEntry{video.Resource, video.Attributes.Name, "music-videos", video.Attributes.PlayParams.CatalogID})
+	}
+	return entries
+}
+
+// mergeSearchResults merges catalog and library results. Catalog items come
+// first, marked as in the library when a library result refers to them,
+// followed by library items without a catalog result.
+func mergeSearchResults(catalog, library *models.SearchResults) []models.SearchItem {
+	var libraryItems []searchEntry
+	owned := make(map[string]string)
+	if library != nil {
+		libraryItems = libraryEntries(library.Results)
+		for _, entry := range libraryItems {
+			if entry.catalogID != "" {
+				owned[entry.catalogType+"/"+entry.catalogID] = entry.resource.ID
+			}
+		}
+	}
+
+	var items []models.SearchItem
+	seen := make(map[string]bool)
+	for _, entry := range catalogEntries(catalog.Results) {
+		key := entry.resource.Type + "/" + entry.resource.ID
+		libraryID, inLibrary := owned[key]
+		seen[key] = true
+		items = append(items, models.SearchItem{
+			Resource:  entry.resource,
+			Name:      entry.name,
+			InLibrary: inLibrary,
+			LibraryID: libraryID,
+		})
+	}
+
+	for _, entry := range libraryItems {
+		if entry.catalogID != "" && seen[entry.catalogType+"/"+entry.catalogID] {
+			continue
+		}
+		items = append(items, models.SearchItem{
+			Resource:  entry.resource,
+			Name:      entry.name,
+			InLibrary: true,
+			LibraryID: entry.resource.ID,
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/marcusziade/musickitkat/mockapi"
+	"github.com/marcusziade/musickitkat/models"
+)
+
+func TestSearchSongsPaginatorLoadsTwoPages(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/search?limit=1&term=mock&types=songs": {Body: `{"results":{"songs":{` +
+			`"data":[{"id":"1","type":"songs","attributes":{"name":"First"}}],` +
+			`"next":"/v1/catalog/us/search?offset=1&term=mock&types=songs"}}}`},
+		"GET catalog/us/search?limit=1&offset=1&term=mock&types=songs": {Body: `{"results":{"songs":{` +
+			`"data":[{"id":"2","type":"songs","attributes":{"name":"Second"}}]}}}`},
+	})
+
+	paginator, err := NewSearchService(c).SearchSongsPaginator(context.Background(), "mock", &models.SearchOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchSongsPaginator() error = %v", err)
+	}
+
+	var names []string
+	for paginator.HasNext() {
+		songs, err := paginator.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		for _, song := range songs {
+			names = append(names, song.Attributes.Name)
+		}
+	}
+
+	if len(names) != 2 || names[0] != "First" || names[1] != "Second" {
+		t.Errorf("songs = %v, want [First Second]", names)
+	}
+
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("got %d requests, want 2", len(requests))
+	}
+
+	if songs, err := paginator.Next(context.Background()); songs != nil || err != nil {
+		t.Errorf("Next() after the last page = %v, %v, want nil, nil", songs, err)
+	}
+}
+
+func TestSearchAllUsesOptionsTypes(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/search?limit=25&term=mock&types=songs": {Body: `{"results":{"songs":{"data":[` +
+			`{"id":"1","type":"songs","attributes":{"name":"Mock Song"}}]}}}`},
+		"GET me/library/search?limit=25&term=mock&types=library-songs": {Body: `{"results":{"library-songs":{"data":[` +
+			`{"id":"i.1","type":"library-songs","attributes":{"name":"Mock Song","playParams":{"id":"i.1","catalogId":"1"}}}]}}}`},
+	})
+
+	results, err := NewSearchService(c).SearchAll(context.Background(), "mock", nil, models.NewSearchOptions().WithTypes("songs").Build())
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+
+	if len(results.Items) != 1 || !results.Items[0].InLibrary || results.Items[0].LibraryID != "i.1" {
+		t.Errorf("SearchAll() items = %+v, want song 1 marked as in the library as i.1", results.Items)
+	}
+
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("got %d requests, want a catalog and a library search", len(requests))
+	}
+}
+
+func TestSearchLibraryUsesOptionsTypes(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET me/library/search": {Body: `{"results":{}}`},
+	})
+
+	_, err := NewSearchService(c).SearchLibrary(context.Background(), "mock", nil, &models.SearchOptions{Types: []string{"library-albums"}})
+	if err != nil {
+		t.Fatalf("SearchLibrary() error = %v", err)
+	}
+
+	if types := server.Requests()[0].URL.Query().Get("types"); types != "library-albums" {
+		t.Errorf("types = %q, want %q", types, "library-albums")
+	}
+}
+
+func TestSearchAllSkipsLibraryWithoutLibraryTypes(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+
+	results, err := NewSearchService(c).SearchAll(context.Background(), "mock", []string{"stations"}, nil)
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v", err)
+	}
+
+	if results.Library != nil || results.LibraryErr != nil {
+		t.Errorf("SearchAll() library = %v, %v, want no library search", results.Library, results.LibraryErr)
+	}
+
+	for _, request := range server.Requests() {
+		if request.URL.Path == "/v1/me/library/search" {
+			t.Errorf("library was searched without library types: %s", request.URL)
+		}
+	}
+}
+
+func TestSearchAllReportsLibraryError(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+	server.Handle("GET me/library/search", http.StatusInternalServerError, `{"errors":[{"status":"500","title":"Internal Server Error"}]}`)
+
+	results, err := NewSearchService(c).SearchAll(context.Background(), "mock", []string{"songs"}, nil)
+	if err != nil {
+		t.Fatalf("SearchAll() error = %v, want the catalog results", err)
+	}
+
+	if results.LibraryErr == nil {
+		t.Error("LibraryErr = nil, want the library search error")
+	}
+
+	if results.Catalog == nil || len(results.Items) != 3 {
+		t.Fatalf("SearchAll() items = %+v, want the three catalog results", results.Items)
+	}
+	for _, item := range results.Items {
+		if item.InLibrary {
+			t.Errorf("item %s is marked as in the library", item.ID)
+		}
+	}
+}
+
+func TestSearchWithTopResults(t *testing.T) {
+	server, c := newMockClient(t, mockapi.Fixtures{
+		"GET catalog/us/search?limit=25&term=mock&types=songs&with=topResults": {Body: `{"results":{` +
+			`"songs":{"data":[{"id":"1","type":"songs","attributes":{"name":"Mock Song"}}]},` +
+			`"top":{"data":[{"id":"1","type":"songs"}]}}}`},
+	})
+
+	options := models.NewSearchOptions().WithSections("topResults").Build()
+	results, err := NewSearchService(c).Search(context.Background(), "mock", []string{"songs"}, options)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if with := server.Requests()[0].URL.Query().Get("with"); with != "topResults" {
+		t.Errorf("with = %q, want %q", with, "topResults")
+	}
+
+	if top := results.Results.TopResults.Data; len(top) != 1 || top[0].ID != "1" || top[0].Type != "songs" {
+		t.Errorf("top results = %+v, want song 1", top)
+	}
+}
+
+func TestSearchDefaultLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  *models.SearchOptions
+		defaults models.QueryParameters
+		want     string
+	}{
+		{"nil options", nil, models.QueryParameters{}, "25"},
+		{"zero limit", &models.SearchOptions{}, models.QueryParameters{}, "25"},
+		{"explicit limit", &models.SearchOptions{Limit: 5}, models.QueryParameters{}, "5"},
+		{"service default", nil, models.QueryParameters{Limit: 10}, "10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, c := newMockClient(t, mockapi.DefaultFixtures())
+			service := NewSearchService(c)
+			service.SetDefaultQueryParameters(tt.defaults)
+
+			if _, err := service.Search(context.Background(), "mock", []string{"songs"}, tt.options); err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+
+			if limit := server.Requests()[0].URL.Query().Get("limit"); limit != tt.want {
+				t.Errorf("limit = %q, want %q", limit, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchFields(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+
+	options := models.NewSearchOptions().WithFields("songs", "name", "artistName").WithFields("albums", "name").Build()
+	if _, err := NewSearchService(c).Search(context.Background(), "mock", []string{"songs", "albums"}, options); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	query := server.Requests()[0].URL.Query()
+	if got := query.Get("fields[songs]"); got != "name,artistName" {
+		t.Errorf("fields[songs] = %q, want %q", got, "name,artistName")
+	}
+	if got := query.Get("fields[albums]"); got != "name" {
+		t.Errorf("fields[albums] = %q, want %q", got, "name")
+	}
+}
+
+func TestSearchLimitByType(t *testing.T) {
+	server, c := newMockClient(t, mockapi.DefaultFixtures())
+
+	options := models.NewSearchOptions().WithLimitForType("songs", 25).WithLimitForType("artists", 5).Build()
+	if _, err := NewSearchService(c).Search(context.Background(), "mock", []string{"songs", "artists"}, options); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	query := server.Requests()[0].URL.Query()
+	for param, want := range map[string]string{"limit[songs]": "25", "limit[artists]": "5", "limit": "25"} {
+		if got := query.Get(param); got != want {
+			t.Errorf("%s = %q, want %q", param, got, want)
+		}
+	}
+}